@@ -3,10 +3,22 @@ package gospecify
 
 import "embed"
 
+//go:generate ./scripts/gen-asset-manifest.sh
+
 //go:embed assets/*
 var AssetsFS embed.FS
 
+// AssetsManifest holds the expected SHA-256 hashes of the embedded assets
+//
+//go:embed assets.sha256
+var AssetsManifest []byte
+
 // GetAssetsFS returns the embedded assets filesystem
 func GetAssetsFS() embed.FS {
 	return AssetsFS
 }
+
+// GetAssetsManifest returns the embedded asset checksum manifest
+func GetAssetsManifest() []byte {
+	return AssetsManifest
+}
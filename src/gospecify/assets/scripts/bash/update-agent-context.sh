@@ -34,8 +34,9 @@
 #    - Can update single agents or all existing agent files
 #    - Creates default Claude file if no agent files exist
 #
-# Usage: ./update-agent-context.sh [agent_type]
+# Usage: ./update-agent-context.sh [agent_type[,agent_type...]]
 # Agent types: claude|gemini|copilot|cursor|qwen|opencode|codex|windsurf
+# Separate several agent types with commas when assistants share the project
 # Leave empty to update all existing agent files
 
 set -e
@@ -694,11 +695,15 @@ main() {
             success=false
         fi
     else
-        # Specific agent provided - update only that agent
-        log_info "Updating specific agent: $AGENT_TYPE"
-        if ! update_specific_agent "$AGENT_TYPE"; then
-            success=false
-        fi
+        # Specific agents provided - update only those agents
+        local agent_types
+        IFS=',' read -ra agent_types <<< "$AGENT_TYPE"
+        for agent_type in "${agent_types[@]}"; do
+            log_info "Updating specific agent: $agent_type"
+            if ! update_specific_agent "$agent_type"; then
+                success=false
+            fi
+        done
     fi
     
     # Print summary
@@ -0,0 +1,122 @@
+#!/usr/bin/env fish
+# Common functions and variables for all scripts
+
+# Get repository root, with fallback for non-git repositories
+function get_repo_root
+    if git rev-parse --show-toplevel >/dev/null 2>&1
+        git rev-parse --show-toplevel
+        return
+    end
+
+    # Fall back to the nearest directory above the scripts holding .specify
+    set -l dir (builtin realpath (status dirname))
+    while test "$dir" != /
+        if test -d "$dir/.specify"
+            echo $dir
+            return
+        end
+        set dir (path dirname $dir)
+    end
+    pwd
+end
+
+# Get current branch, with fallback for non-git repositories
+function get_current_branch
+    # First check if SPECIFY_FEATURE environment variable is set
+    if set -q SPECIFY_FEATURE; and test -n "$SPECIFY_FEATURE"
+        echo $SPECIFY_FEATURE
+        return
+    end
+
+    # Then check git if available
+    if git rev-parse --abbrev-ref HEAD >/dev/null 2>&1
+        git rev-parse --abbrev-ref HEAD
+        return
+    end
+
+    # For non-git repos, try to find the latest feature directory
+    set -l specs_dir (get_repo_root)/specs
+    set -l latest_feature ""
+    set -l highest 0
+    for dir in $specs_dir/*/
+        set -l dirname (path basename $dir)
+        if string match -qr '^([0-9]{3})-' -- $dirname
+            set -l number (math (string sub -l 3 -- $dirname))
+            if test $number -gt $highest
+                set highest $number
+                set latest_feature $dirname
+            end
+        end
+    end
+
+    if test -n "$latest_feature"
+        echo $latest_feature
+        return
+    end
+
+    echo main # Final fallback
+end
+
+# Check if we have git available
+function has_git
+    git rev-parse --show-toplevel >/dev/null 2>&1
+end
+
+function check_feature_branch --argument-names branch has_git_repo
+    # For non-git repos, we can't enforce branch naming but still provide output
+    if test "$has_git_repo" != true
+        echo "[specify] Warning: Git repository not detected; skipped branch validation" >&2
+        return 0
+    end
+
+    if not string match -qr '^[0-9]{3}-' -- $branch
+        echo "ERROR: Not on a feature branch. Current branch: $branch" >&2
+        echo "Feature branches should be named like: 001-feature-name" >&2
+        return 1
+    end
+
+    return 0
+end
+
+function get_feature_dir --argument-names repo_root branch
+    echo "$repo_root/specs/$branch"
+end
+
+# Set the feature path variables globally
+function get_feature_paths
+    set -g REPO_ROOT (get_repo_root)
+    set -g CURRENT_BRANCH (get_current_branch)
+    set -g HAS_GIT false
+    if has_git
+        set HAS_GIT true
+    end
+
+    set -g FEATURE_DIR (get_feature_dir $REPO_ROOT $CURRENT_BRANCH)
+    set -g FEATURE_SPEC "$FEATURE_DIR/spec.md"
+    set -g IMPL_PLAN "$FEATURE_DIR/plan.md"
+    set -g TASKS "$FEATURE_DIR/tasks.md"
+    set -g RESEARCH "$FEATURE_DIR/research.md"
+    set -g DATA_MODEL "$FEATURE_DIR/data-model.md"
+    set -g QUICKSTART "$FEATURE_DIR/quickstart.md"
+    set -g CONTRACTS_DIR "$FEATURE_DIR/contracts"
+end
+
+function dir_has_files --argument-names dir
+    test -d "$dir"; and test (count $dir/* $dir/.*) -gt 0
+end
+
+function check_file --argument-names file label
+    if test -f "$file"
+        echo "  ✓ $label"
+    else
+        echo "  ✗ $label"
+    end
+end
+
+function check_dir --argument-names dir label
+    if dir_has_files $dir
+        echo "  ✓ $label"
+    else
+        echo "  ✗ $label"
+    end
+end
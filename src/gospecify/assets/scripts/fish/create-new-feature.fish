@@ -0,0 +1,94 @@
+#!/usr/bin/env fish
+
+if not argparse json h/help -- $argv
+    echo "Usage: "(status filename)" [--json] <feature_description>" >&2
+    exit 1
+end
+
+if set -q _flag_help
+    echo "Usage: "(status filename)" [--json] <feature_description>"
+    exit 0
+end
+
+set -l feature_description (string join ' ' -- $argv)
+if test -z "$feature_description"
+    echo "Usage: "(status filename)" [--json] <feature_description>" >&2
+    exit 1
+end
+
+# Find the repository root by searching for existing project markers
+function find_repo_root --argument-names dir
+    while test "$dir" != /
+        if test -d "$dir/.git"; or test -d "$dir/.specify"
+            echo $dir
+            return 0
+        end
+        set dir (path dirname $dir)
+    end
+    return 1
+end
+
+# Resolve repository root. Prefer git information when available, but fall back
+# to searching for repository markers so the workflow still functions in repositories that
+# were initialised with --no-git.
+set -l script_dir (builtin realpath (status dirname))
+
+set -l repo_root
+set -l has_git false
+if git rev-parse --show-toplevel >/dev/null 2>&1
+    set repo_root (git rev-parse --show-toplevel)
+    set has_git true
+else
+    set repo_root (find_repo_root $script_dir)
+    if test -z "$repo_root"
+        echo "Error: Could not determine repository root. Please run this script from within the repository." >&2
+        exit 1
+    end
+end
+
+cd $repo_root; or exit 1
+
+set -l specs_dir "$repo_root/specs"
+mkdir -p $specs_dir
+
+set -l highest 0
+for dir in $specs_dir/*/
+    set -l number (string match -r '^[0-9]+' -- (path basename $dir))
+    if test -n "$number"; and test (math $number) -gt $highest
+        set highest (math $number)
+    end
+end
+
+set -l feature_num (printf "%03d" (math $highest + 1))
+
+set -l words (string lower -- $feature_description | string replace -ra '[^a-z0-9]+' '-' | string trim -c - | string split -n - )
+set -l branch_name "$feature_num-"(string join - -- $words[1..3])
+
+if test $has_git = true
+    git checkout -b $branch_name; or exit 1
+else
+    echo "[specify] Warning: Git repository not detected; skipped branch creation for $branch_name" >&2
+end
+
+set -l feature_dir "$specs_dir/$branch_name"
+mkdir -p $feature_dir
+
+set -l template "$repo_root/.specify/templates/spec-template.md"
+set -l spec_file "$feature_dir/spec.md"
+if test -f $template
+    cp $template $spec_file
+else
+    touch $spec_file
+end
+
+# Set the SPECIFY_FEATURE environment variable for the current session
+set -gx SPECIFY_FEATURE $branch_name
+
+if set -q _flag_json
+    printf '{"BRANCH_NAME":"%s","SPEC_FILE":"%s","FEATURE_NUM":"%s"}\n' $branch_name $spec_file $feature_num
+else
+    echo "BRANCH_NAME: $branch_name"
+    echo "SPEC_FILE: $spec_file"
+    echo "FEATURE_NUM: $feature_num"
+    echo "SPECIFY_FEATURE environment variable set to: $branch_name"
+end
@@ -0,0 +1,48 @@
+#!/usr/bin/env fish
+
+# Parse command line arguments
+if not argparse json h/help -- $argv
+    exit 1
+end
+
+if set -q _flag_help
+    echo "Usage: "(status filename)" [--json]"
+    echo "  --json    Output results in JSON format"
+    echo "  --help    Show this help message"
+    exit 0
+end
+
+# Load common functions
+source (status dirname)/common.fish
+
+# Get all paths and variables from common functions
+get_feature_paths
+
+# Check if we're on a proper feature branch (only for git repos)
+check_feature_branch $CURRENT_BRANCH $HAS_GIT; or exit 1
+
+# Ensure the feature directory exists
+mkdir -p $FEATURE_DIR
+
+# Copy plan template if it exists
+set -l template "$REPO_ROOT/.specify/templates/plan-template.md"
+if test -f $template
+    cp $template $IMPL_PLAN
+    echo "Copied plan template to $IMPL_PLAN"
+else
+    echo "Warning: Plan template not found at $template"
+    # Create a basic plan file if template doesn't exist
+    touch $IMPL_PLAN
+end
+
+# Output results
+if set -q _flag_json
+    printf '{"FEATURE_SPEC":"%s","IMPL_PLAN":"%s","SPECS_DIR":"%s","BRANCH":"%s","HAS_GIT":"%s"}\n' \
+        $FEATURE_SPEC $IMPL_PLAN $FEATURE_DIR $CURRENT_BRANCH $HAS_GIT
+else
+    echo "FEATURE_SPEC: $FEATURE_SPEC"
+    echo "IMPL_PLAN: $IMPL_PLAN"
+    echo "SPECS_DIR: $FEATURE_DIR"
+    echo "BRANCH: $CURRENT_BRANCH"
+    echo "HAS_GIT: $HAS_GIT"
+end
@@ -0,0 +1,394 @@
+#!/usr/bin/env fish
+
+# Update agent context files with information from plan.md
+#
+# This script maintains AI agent context files by parsing the current feature's
+# plan.md and updating agent-specific context files with project information:
+# the language, frameworks and storage in use, the project structure, and the
+# most recent changes. New files are created from the agent file template,
+# existing ones are updated in place, keeping manual additions.
+#
+# Usage: ./update-agent-context.fish [agent_type[,agent_type...]]
+# Agent types: claude|gemini|copilot|cursor|qwen|opencode|codex|windsurf|kilocode|auggie|roo
+# Separate several agent types with commas when assistants share the project
+# Leave empty to update all existing agent files
+
+# Load common functions and all paths
+source (status dirname)/common.fish
+get_feature_paths
+
+set -g NEW_PLAN $IMPL_PLAN
+set -g AGENT_TYPE (string join , -- $argv)
+
+set -g CLAUDE_FILE "$REPO_ROOT/CLAUDE.md"
+set -g GEMINI_FILE "$REPO_ROOT/GEMINI.md"
+set -g COPILOT_FILE "$REPO_ROOT/.github/copilot-instructions.md"
+set -g CURSOR_FILE "$REPO_ROOT/.cursor/rules/specify-rules.mdc"
+set -g QWEN_FILE "$REPO_ROOT/QWEN.md"
+set -g AGENTS_FILE "$REPO_ROOT/AGENTS.md"
+set -g WINDSURF_FILE "$REPO_ROOT/.windsurf/rules/specify-rules.md"
+set -g KILOCODE_FILE "$REPO_ROOT/.kilocode/rules/specify-rules.md"
+set -g AUGGIE_FILE "$REPO_ROOT/.augment/rules/specify-rules.md"
+set -g ROO_FILE "$REPO_ROOT/.roo/rules/specify-rules.md"
+
+set -g TEMPLATE_FILE "$REPO_ROOT/.specify/templates/agent-file-template.md"
+
+set -g NEW_LANG ""
+set -g NEW_FRAMEWORK ""
+set -g NEW_DB ""
+set -g NEW_PROJECT_TYPE ""
+
+function log_info
+    echo "INFO: $argv[1]"
+end
+
+function log_success
+    echo "✓ $argv[1]"
+end
+
+function log_error
+    echo "ERROR: $argv[1]" >&2
+end
+
+function log_warning
+    echo "WARNING: $argv[1]" >&2
+end
+
+function validate_environment
+    if test -z "$CURRENT_BRANCH"
+        log_error "Unable to determine current feature"
+        if test "$HAS_GIT" = true
+            log_info "Make sure you're on a feature branch"
+        else
+            log_info "Set SPECIFY_FEATURE environment variable or create a feature first"
+        end
+        exit 1
+    end
+
+    if not test -f $NEW_PLAN
+        log_error "No plan.md found at $NEW_PLAN"
+        log_info "Make sure you're working on a feature with a corresponding spec directory"
+        if test "$HAS_GIT" != true
+            log_info "Use: set -gx SPECIFY_FEATURE your-feature-name or create a new feature first"
+        end
+        exit 1
+    end
+
+    if not test -f $TEMPLATE_FILE
+        log_warning "Template file not found at $TEMPLATE_FILE"
+        log_warning "Creating new agent files will fail"
+    end
+end
+
+# Print the value of a "**Field**: value" line in the plan, skipping placeholders
+function extract_plan_field --argument-names field_pattern plan_file
+    set -l prefix "**$field_pattern**: "
+    for line in (cat $plan_file 2>/dev/null)
+        set -l head (string sub -l (string length -- $prefix) -- $line)
+        if test "$head" = "$prefix"
+            set -l value (string trim -- (string sub -s (math (string length -- $prefix) + 1) -- $line))
+            if not string match -q -- "*NEEDS CLARIFICATION*" $value; and test "$value" != N/A
+                echo $value
+            end
+            return
+        end
+    end
+end
+
+function parse_plan_data --argument-names plan_file
+    if not test -r $plan_file
+        log_error "Plan file is not readable: $plan_file"
+        return 1
+    end
+
+    log_info "Parsing plan data from $plan_file"
+
+    set -g NEW_LANG (extract_plan_field "Language/Version" $plan_file)
+    set -g NEW_FRAMEWORK (extract_plan_field "Primary Dependencies" $plan_file)
+    set -g NEW_DB (extract_plan_field "Storage" $plan_file)
+    set -g NEW_PROJECT_TYPE (extract_plan_field "Project Type" $plan_file)
+
+    if test -n "$NEW_LANG"
+        log_info "Found language: $NEW_LANG"
+    else
+        log_warning "No language information found in plan"
+    end
+    test -n "$NEW_FRAMEWORK"; and log_info "Found framework: $NEW_FRAMEWORK"
+    test -n "$NEW_DB"; and log_info "Found database: $NEW_DB"
+    test -n "$NEW_PROJECT_TYPE"; and log_info "Found project type: $NEW_PROJECT_TYPE"
+    return 0
+end
+
+function format_technology_stack --argument-names lang framework
+    set -l parts
+    test -n "$lang"; and set -a parts $lang
+    test -n "$framework"; and set -a parts $framework
+    string join " + " -- $parts
+end
+
+function get_project_structure --argument-names project_type
+    if string match -q -- "*web*" $project_type
+        printf 'backend/\nfrontend/\ntests/'
+    else
+        printf 'src/\ntests/'
+    end
+end
+
+function get_commands_for_language --argument-names lang
+    switch $lang
+        case "*Python*"
+            echo "cd src && pytest && ruff check ."
+        case "*Rust*"
+            echo "cargo test && cargo clippy"
+        case "*JavaScript*" "*TypeScript*"
+            echo "npm test && npm run lint"
+        case "*"
+            echo "# Add commands for $lang"
+    end
+end
+
+function create_new_agent_file --argument-names target_file project_name current_date
+    if not test -r $TEMPLATE_FILE
+        log_error "Template not found at $TEMPLATE_FILE"
+        return 1
+    end
+
+    log_info "Creating new agent context file from template..."
+
+    set -l tech_stack (format_technology_stack $NEW_LANG $NEW_FRAMEWORK)
+    set -l recent_change "- $CURRENT_BRANCH: Added"
+    if test -n "$tech_stack"
+        set tech_stack "- $tech_stack ($CURRENT_BRANCH)"
+        set recent_change "- $CURRENT_BRANCH: Added "(format_technology_stack $NEW_LANG $NEW_FRAMEWORK)
+    else
+        set tech_stack "- ($CURRENT_BRANCH)"
+    end
+
+    cat $TEMPLATE_FILE | string collect \
+        | string replace -- "[PROJECT NAME]" $project_name \
+        | string replace -- "[DATE]" $current_date \
+        | string replace -- "[EXTRACTED FROM ALL PLAN.MD FILES]" $tech_stack \
+        | string replace -a -- "[ACTUAL STRUCTURE FROM PLANS]" (get_project_structure $NEW_PROJECT_TYPE | string collect) \
+        | string replace -- "[ONLY COMMANDS FOR ACTIVE TECHNOLOGIES]" (get_commands_for_language $NEW_LANG) \
+        | string replace -- "[LANGUAGE-SPECIFIC, ONLY FOR LANGUAGES IN USE]" "$NEW_LANG: Follow standard conventions" \
+        | string replace -- "[LAST 3 FEATURES AND WHAT THEY ADDED]" $recent_change \
+        > $target_file
+end
+
+function update_existing_agent_file --argument-names target_file current_date
+    log_info "Updating existing agent context file..."
+
+    set -l tech_stack (format_technology_stack $NEW_LANG $NEW_FRAMEWORK)
+    set -l content (cat $target_file | string collect)
+    set -l new_tech_entries
+    set -l new_change_entry ""
+
+    if test -n "$tech_stack"; and not string match -q -- "*$tech_stack*" $content
+        set -a new_tech_entries "- $tech_stack ($CURRENT_BRANCH)"
+    end
+    if test -n "$NEW_DB"; and not string match -q -- "*$NEW_DB*" $content
+        set -a new_tech_entries "- $NEW_DB ($CURRENT_BRANCH)"
+    end
+
+    if test -n "$tech_stack"
+        set new_change_entry "- $CURRENT_BRANCH: Added $tech_stack"
+    else if test -n "$NEW_DB"
+        set new_change_entry "- $CURRENT_BRANCH: Added $NEW_DB"
+    end
+
+    set -l output
+    set -l in_tech_section false
+    set -l in_changes_section false
+    set -l tech_entries_added false
+    set -l existing_changes_count 0
+
+    for line in (cat $target_file)
+        # Handle Active Technologies section
+        if test "$line" = "## Active Technologies"
+            set -a output $line
+            set in_tech_section true
+            continue
+        else if test $in_tech_section = true; and string match -qr '^##\s' -- $line
+            if test $tech_entries_added = false
+                set -a output $new_tech_entries
+                set tech_entries_added true
+            end
+            set -a output $line
+            set in_tech_section false
+            continue
+        else if test $in_tech_section = true; and test -z "$line"
+            if test $tech_entries_added = false
+                set -a output $new_tech_entries
+                set tech_entries_added true
+            end
+            set -a output $line
+            continue
+        end
+
+        # Handle Recent Changes section, keeping the two most recent entries
+        if test "$line" = "## Recent Changes"
+            set -a output $line
+            test -n "$new_change_entry"; and set -a output $new_change_entry
+            set in_changes_section true
+            continue
+        else if test $in_changes_section = true; and string match -qr '^##\s' -- $line
+            set -a output $line
+            set in_changes_section false
+            continue
+        else if test $in_changes_section = true; and string match -q -- "- *" $line
+            if test $existing_changes_count -lt 2
+                set -a output $line
+                set existing_changes_count (math $existing_changes_count + 1)
+            end
+            continue
+        end
+
+        # Update timestamp
+        if string match -qr '\*\*Last updated\*\*:.*[0-9]{4}-[0-9]{2}-[0-9]{2}' -- $line
+            set -a output (string replace -r '[0-9]{4}-[0-9]{2}-[0-9]{2}' $current_date -- $line)
+        else
+            set -a output $line
+        end
+    end
+
+    # Post-loop check: still in the Active Technologies section at the end of the file
+    if test $in_tech_section = true; and test $tech_entries_added = false
+        set -a output $new_tech_entries
+    end
+
+    printf '%s\n' $output > $target_file
+end
+
+function update_agent_file --argument-names target_file agent_name
+    log_info "Updating $agent_name context file: $target_file"
+
+    set -l project_name (path basename $REPO_ROOT)
+    set -l current_date (date +%Y-%m-%d)
+
+    if not mkdir -p (path dirname $target_file)
+        log_error "Failed to create directory: "(path dirname $target_file)
+        return 1
+    end
+
+    if not test -f $target_file
+        set -l temp_file (mktemp)
+        if create_new_agent_file $temp_file $project_name $current_date; and mv $temp_file $target_file
+            log_success "Created new $agent_name context file"
+        else
+            log_error "Failed to create new agent file"
+            rm -f $temp_file
+            return 1
+        end
+    else
+        if not test -r $target_file; or not test -w $target_file
+            log_error "Cannot read or write existing file: $target_file"
+            return 1
+        end
+        if update_existing_agent_file $target_file $current_date
+            log_success "Updated existing $agent_name context file"
+        else
+            log_error "Failed to update existing agent file"
+            return 1
+        end
+    end
+    return 0
+end
+
+function update_specific_agent --argument-names agent_type
+    switch $agent_type
+        case claude
+            update_agent_file $CLAUDE_FILE "Claude Code"
+        case gemini
+            update_agent_file $GEMINI_FILE "Gemini CLI"
+        case copilot
+            update_agent_file $COPILOT_FILE "GitHub Copilot"
+        case cursor
+            update_agent_file $CURSOR_FILE "Cursor IDE"
+        case qwen
+            update_agent_file $QWEN_FILE "Qwen Code"
+        case opencode
+            update_agent_file $AGENTS_FILE opencode
+        case codex
+            update_agent_file $AGENTS_FILE "Codex CLI"
+        case windsurf
+            update_agent_file $WINDSURF_FILE Windsurf
+        case kilocode
+            update_agent_file $KILOCODE_FILE "Kilo Code"
+        case auggie
+            update_agent_file $AUGGIE_FILE "Auggie CLI"
+        case roo
+            update_agent_file $ROO_FILE "Roo Code"
+        case "*"
+            log_error "Unknown agent type '$agent_type'"
+            log_error "Expected: claude|gemini|copilot|cursor|qwen|opencode|codex|windsurf|kilocode|auggie|roo"
+            exit 1
+    end
+end
+
+function update_all_existing_agents
+    set -l found_agent false
+    set -l status_ok true
+    for entry in \
+            "$CLAUDE_FILE|Claude Code" \
+            "$GEMINI_FILE|Gemini CLI" \
+            "$COPILOT_FILE|GitHub Copilot" \
+            "$CURSOR_FILE|Cursor IDE" \
+            "$QWEN_FILE|Qwen Code" \
+            "$AGENTS_FILE|Codex/opencode" \
+            "$WINDSURF_FILE|Windsurf" \
+            "$KILOCODE_FILE|Kilo Code" \
+            "$AUGGIE_FILE|Auggie CLI" \
+            "$ROO_FILE|Roo Code"
+        set -l parts (string split -m 1 -r '|' -- $entry)
+        if test -f $parts[1]
+            update_agent_file $parts[1] $parts[2]; or set status_ok false
+            set found_agent true
+        end
+    end
+
+    # If no agent files exist, create a default Claude file
+    if test $found_agent = false
+        log_info "No existing agent files found, creating default Claude file..."
+        update_agent_file $CLAUDE_FILE "Claude Code"; or set status_ok false
+    end
+    test $status_ok = true
+end
+
+function print_summary
+    echo
+    log_info "Summary of changes:"
+    test -n "$NEW_LANG"; and echo "  - Added language: $NEW_LANG"
+    test -n "$NEW_FRAMEWORK"; and echo "  - Added framework: $NEW_FRAMEWORK"
+    test -n "$NEW_DB"; and echo "  - Added database: $NEW_DB"
+    echo
+    log_info "Usage: "(status filename)" [claude|gemini|copilot|cursor|qwen|opencode|codex|windsurf|kilocode|auggie|roo]"
+end
+
+validate_environment
+
+log_info "=== Updating agent context files for feature $CURRENT_BRANCH ==="
+
+if not parse_plan_data $NEW_PLAN
+    log_error "Failed to parse plan data"
+    exit 1
+end
+
+set -l success true
+if test -z "$AGENT_TYPE"
+    log_info "No agent specified, updating all existing agent files..."
+    update_all_existing_agents; or set success false
+else
+    for agent_type in (string split , -- $AGENT_TYPE)
+        log_info "Updating specific agent: $agent_type"
+        update_specific_agent $agent_type; or set success false
+    end
+end
+
+print_summary
+
+if test $success = true
+    log_success "Agent context update completed successfully"
+    exit 0
+end
+log_error "Agent context update completed with errors"
+exit 1
@@ -12,7 +12,7 @@ Mirrors the behavior of scripts/bash/update-agent-context.sh:
  5. Multi-Agent Support (claude, gemini, copilot, cursor, qwen, opencode, codex, windsurf)
 
 .PARAMETER AgentType
-Optional agent key to update a single agent. If omitted, updates all existing agent files (creating a default Claude file if none exist).
+Optional agent key to update a single agent, or several comma-separated keys when assistants share the project. If omitted, updates all existing agent files (creating a default Claude file if none exist).
 
 .EXAMPLE
 ./update-agent-context.ps1 -AgentType claude
@@ -25,7 +25,6 @@ Relies on common helper functions in common.ps1
 #>
 param(
     [Parameter(Position=0)]
-    [ValidateSet('claude','gemini','copilot','cursor','qwen','opencode','codex','windsurf','kilocode','auggie','roo')]
     [string]$AgentType
 )
 
@@ -416,8 +415,10 @@ function Main {
     if (-not (Parse-PlanData -PlanFile $NEW_PLAN)) { Write-Err 'Failed to parse plan data'; exit 1 }
     $success = $true
     if ($AgentType) {
-        Write-Info "Updating specific agent: $AgentType"
-        if (-not (Update-SpecificAgent -Type $AgentType)) { $success = $false }
+        foreach ($type in ($AgentType -split '[,\s]+')) {
+            Write-Info "Updating specific agent: $type"
+            if (-not (Update-SpecificAgent -Type $type.Trim())) { $success = $false }
+        }
     }
     else {
         Write-Info 'No agent specified, updating all existing agent files...'
@@ -0,0 +1,230 @@
+// Package cmd provides the CLI commands for gospecify
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/ui"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// sensitivePatterns match file names in agent folders that commonly hold
+// tokens, API keys or login sessions
+var sensitivePatterns = []string{
+	".credentials.json",
+	"credentials*.json",
+	"auth.json",
+	"oauth_creds.json",
+	"settings.local.json",
+	"mcp.json",
+	"*token*",
+	"*secret*",
+	".env",
+	"*.env",
+	"*.pem",
+	"*.key",
+}
+
+// auditFinding is a sensitive file found in an agent folder
+type auditFinding struct {
+	path    string
+	ignored bool
+	tracked bool
+}
+
+// NewAuditCmd creates the audit command
+func NewAuditCmd() *cobra.Command {
+	var strict bool
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Report credential files in agent folders that git would commit",
+		Long: `Scan the agent folders of the project in the current directory for files
+that commonly hold secrets (token files, auth and credential JSON, keys)
+and report any that are not covered by .gitignore.
+
+Examples:
+  gospecify audit
+  gospecify audit --strict`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			theme, err := loadTheme(cmd)
+			if err != nil {
+				return err
+			}
+			return runAudit(theme, strict)
+		},
+	}
+
+	cmd.Flags().BoolVar(&strict, "strict", false,
+		"Exit with an error when a sensitive file is not ignored by git")
+
+	return cmd
+}
+
+// runAudit executes the audit command
+func runAudit(theme *ui.Theme, strict bool) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to get current directory", err)
+	}
+
+	fmt.Println(theme.InfoPanel.Render(fmt.Sprintf("🔐 Auditing agent folders in %s", projectPath)))
+	fmt.Println()
+
+	findings, err := auditAgentFolders(projectPath)
+	if err != nil {
+		return err
+	}
+
+	if len(findings) == 0 {
+		fmt.Println(theme.SuccessPanel.Render("🎉 No credential files found in agent folders"))
+		return nil
+	}
+
+	var exposed int
+	for _, finding := range findings {
+		switch {
+		case finding.tracked:
+			exposed++
+			fmt.Printf("❌ %s - tracked by git\n", finding.path)
+		case !finding.ignored:
+			exposed++
+			fmt.Printf("❌ %s - not ignored, would be committed\n", finding.path)
+		default:
+			fmt.Printf("✅ %s - ignored\n", finding.path)
+		}
+	}
+	fmt.Println()
+
+	if exposed == 0 {
+		fmt.Println(theme.SuccessPanel.Render(fmt.Sprintf("🎉 All %d credential files are ignored by git", len(findings))))
+		return nil
+	}
+
+	fmt.Println(theme.WarningPanel.Render(fmt.Sprintf(
+		"⚠️  %d of %d credential files would be committed.\nAdd them (or their agent folder) to .gitignore; committed files must also be removed with 'git rm --cached'.",
+		exposed, len(findings))))
+
+	if strict {
+		return errors.NewValidationError(fmt.Sprintf("%d credential files are not ignored by git", exposed))
+	}
+	return nil
+}
+
+// auditAgentFolders returns the sensitive files found in the agent folders of
+// projectPath, sorted by path
+func auditAgentFolders(projectPath string) ([]auditFinding, error) {
+	folders := make(map[string]bool)
+	for _, folder := range config.AgentFolderMap {
+		folders[strings.TrimSuffix(folder, "/")] = true
+	}
+
+	var sensitive []string
+	for folder := range folders {
+		root := filepath.Join(projectPath, folder)
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			continue
+		}
+
+		err := filepath.WalkDir(root, func(fullPath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() || !isSensitiveName(entry.Name()) {
+				return nil
+			}
+			relPath, err := filepath.Rel(projectPath, fullPath)
+			if err != nil {
+				return err
+			}
+			sensitive = append(sensitive, filepath.ToSlash(relPath))
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrCodeFileSystemError, fmt.Sprintf("failed to scan %s", folder), err)
+		}
+	}
+	sort.Strings(sensitive)
+
+	inGit := exec.Command("git", "-C", projectPath, "rev-parse", "--is-inside-work-tree").Run() == nil
+	var patterns []string
+	if !inGit {
+		if data, err := os.ReadFile(filepath.Join(projectPath, ".gitignore")); err == nil {
+			patterns = strings.Split(string(data), "\n")
+		}
+	}
+
+	findings := make([]auditFinding, 0, len(sensitive))
+	for _, relPath := range sensitive {
+		finding := auditFinding{path: relPath}
+		if inGit {
+			finding.ignored = exec.Command("git", "-C", projectPath, "check-ignore", "-q", "--", relPath).Run() == nil
+			finding.tracked = exec.Command("git", "-C", projectPath, "ls-files", "--error-unmatch", "--", relPath).Run() == nil
+		} else {
+			finding.ignored = matchesGitignore(patterns, relPath)
+		}
+		findings = append(findings, finding)
+	}
+
+	return findings, nil
+}
+
+// isSensitiveName reports whether a file name matches sensitivePatterns
+func isSensitiveName(name string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range sensitivePatterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesGitignore approximates git's rules for a root .gitignore, for
+// projects that are not yet a git repository. Later patterns win, and "!"
+// re-includes a path.
+func matchesGitignore(patterns []string, relPath string) bool {
+	segments := strings.Split(relPath, "/")
+	ignored := false
+
+	for _, line := range patterns {
+		pattern := strings.TrimSpace(line)
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+
+		negate := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimPrefix(pattern, "!")
+		dirOnly := strings.HasSuffix(pattern, "/")
+		pattern = strings.TrimSuffix(pattern, "/")
+		anchored := strings.Contains(pattern, "/")
+		pattern = strings.TrimPrefix(pattern, "/")
+
+		for i := range segments {
+			// A directory pattern only matches the folders above the file
+			if dirOnly && i == len(segments)-1 {
+				break
+			}
+			candidate := segments[i]
+			if anchored {
+				candidate = strings.Join(segments[:i+1], "/")
+			}
+			if matched, _ := path.Match(pattern, candidate); matched {
+				ignored = !negate
+				break
+			}
+		}
+	}
+
+	return ignored
+}
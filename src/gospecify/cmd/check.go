@@ -2,17 +2,35 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
 	"github.com/jsburckhardt/spec-kit/gospecify/internal/ui"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
+// toolProbeTimeout bounds each invocation made by check --deep
+const toolProbeTimeout = 10 * time.Second
+
+// toolProbeArgs lists the harmless arguments used to invoke a tool; tools
+// not listed are run with --help
+var toolProbeArgs = map[string][]string{
+	"git": {"--version"},
+}
+
 // NewCheckCmd creates the check command
 func NewCheckCmd() *cobra.Command {
-	return &cobra.Command{
+	var deep bool
+	var simulateMissing []string
+	var aiAssistant string
+
+	cmd := &cobra.Command{
 		Use:   "check",
 		Short: "Check that required tools are installed",
 		Long: `Check that required tools are installed for gospecify to work properly.
@@ -21,30 +39,107 @@ This command verifies that all necessary tools are available:
 - Git (optional, but recommended)
 - AI assistant CLI tools (optional, checked per assistant)
 
+With --deep, each tool found on PATH is also invoked with a harmless
+argument such as --help to confirm it actually runs.
+
+With --ai, only git and that assistant's CLI are checked, and the command
+fails when the assistant's CLI is missing or broken, for use as a CI gate.
+
 Examples:
-  gospecify check`,
+  gospecify check
+  gospecify check --deep
+  gospecify check --ai claude`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runCheck()
+			theme, err := loadTheme(cmd)
+			if err != nil {
+				return err
+			}
+			return runCheck(theme, deep, simulateMissing, aiAssistant)
 		},
 	}
+
+	cmd.Flags().StringVar(&aiAssistant, "ai", "",
+		"Only check the tools needed by this AI assistant, failing if they are missing")
+	cmd.Flags().BoolVar(&deep, "deep", false,
+		fmt.Sprintf("Run each tool found to confirm it works (each bounded by a %s timeout)", toolProbeTimeout))
+
+	// Testing aid: lets CI exercise the missing-tool output without uninstalling anything
+	cmd.Flags().StringSliceVar(&simulateMissing, "simulate-missing", nil,
+		"Testing aid: treat these comma-separated tools as absent regardless of PATH")
+	_ = cmd.Flags().MarkHidden("simulate-missing")
+
+	_ = cmd.RegisterFlagCompletionFunc("ai", completeAssistants(false))
+
+	return cmd
 }
 
 // runCheck executes the check command
-func runCheck() error {
-	fmt.Println(ui.InfoPanel.Render("🔍 Checking system for required tools..."))
+func runCheck(theme *ui.Theme, deep bool, simulateMissing []string, aiAssistant string) error {
+	// Every assistant, or only the requested one, in key order
+	var assistants []config.AIAssistant
+	if aiAssistant != "" {
+		assistant, exists := config.AIAssistants[aiAssistant]
+		if !exists {
+			return errors.NewValidationError(fmt.Sprintf(
+				"unknown AI assistant %q (run 'gospecify list' to see the supported ones)", aiAssistant))
+		}
+		assistants = append(assistants, assistant)
+	} else {
+		for _, assistant := range config.AIAssistants {
+			assistants = append(assistants, assistant)
+		}
+		sort.Slice(assistants, func(i, j int) bool { return assistants[i].Key < assistants[j].Key })
+	}
+
+	fmt.Println(theme.InfoPanel.Render("🔍 Checking system for required tools..."))
 	fmt.Println()
 
-	// Check results
+	simulated := make(map[string]bool)
+	for _, tool := range simulateMissing {
+		if tool = strings.TrimSpace(tool); tool != "" {
+			simulated[tool] = true
+		}
+	}
+	if len(simulated) > 0 {
+		names := make([]string, 0, len(simulated))
+		for tool := range simulated {
+			names = append(names, tool)
+		}
+		sort.Strings(names)
+		fmt.Printf("🧪 Simulating missing tools: %s\n", strings.Join(names, ", "))
+		fmt.Println()
+	}
+
+	// Check results, with tools in display order
 	results := make(map[string]bool)
+	tools := []string{"git"}
 
 	// Check git
-	results["git"] = checkTool("git", "Version control system")
+	results["git"] = !simulated["git"] && checkTool("git", "Version control system")
+
+	// Check AI assistant tools; IDE-based assistants have no CLI to look for
+	var ideBased []string
+	for _, assistant := range assistants {
+		if assistant.CLITool == "" {
+			ideBased = append(ideBased, assistant.Name)
+			continue
+		}
+		if _, checked := results[assistant.CLITool]; checked {
+			continue
+		}
+		tools = append(tools, assistant.CLITool)
+		results[assistant.CLITool] = !simulated[assistant.CLITool] && checkTool(assistant.CLITool, fmt.Sprintf("CLI for %s", assistant.Name))
+	}
 
-	// Check AI assistant tools
-	assistantTools := []string{"claude", "gemini", "cursor", "qwen", "opencode", "codex", "kilocode", "auggie", "roo"}
-	for _, tool := range assistantTools {
-		if assistant, exists := config.AIAssistants[tool]; exists && assistant.CLITool != "" {
-			results[assistant.CLITool] = checkTool(assistant.CLITool, fmt.Sprintf("CLI for %s", assistant.Name))
+	// Invoke the tools that were found to catch broken installs
+	broken := make(map[string]error)
+	if deep {
+		for tool, available := range results {
+			if available {
+				if err := probeTool(tool); err != nil {
+					broken[tool] = err
+				}
+			}
 		}
 	}
 
@@ -53,8 +148,12 @@ func runCheck() error {
 	fmt.Println()
 
 	allGood := true
-	for tool, available := range results {
-		if available {
+	for _, tool := range tools {
+		available := results[tool]
+		if probeErr, isBroken := broken[tool]; isBroken {
+			fmt.Printf("⚠️  %s - Installed but not working (%v)\n", tool, probeErr)
+			allGood = false
+		} else if available {
 			fmt.Printf("✅ %s - Available\n", tool)
 		} else {
 			fmt.Printf("❌ %s - Not found\n", tool)
@@ -62,12 +161,35 @@ func runCheck() error {
 		}
 	}
 
+	for _, name := range ideBased {
+		fmt.Printf("ℹ️  %s - IDE-based, no CLI tool required\n", name)
+	}
+
 	fmt.Println()
 
+	// The requested assistant cannot work without its CLI
+	if aiAssistant != "" {
+		if tool := assistants[0].CLITool; tool != "" {
+			if _, isBroken := broken[tool]; isBroken || !results[tool] {
+				fmt.Println(theme.WarningPanel.Render(fmt.Sprintf(
+					"⚠️  %s needs %s, which is missing or not working.", assistants[0].Name, tool)))
+				return errors.NewToolNotFound(tool)
+			}
+		}
+	}
+
 	if allGood {
-		fmt.Println(ui.SuccessPanel.Render("🎉 All tools are properly installed!"))
+		fmt.Println(theme.SuccessPanel.Render("🎉 All tools are properly installed!"))
+	} else if len(broken) > 0 {
+		var names []string
+		for tool := range broken {
+			names = append(names, tool)
+		}
+		sort.Strings(names)
+		fmt.Println(theme.WarningPanel.Render(fmt.Sprintf(
+			"⚠️  Found on PATH but failed to run: %s\nReinstall them before using their AI assistants.", strings.Join(names, ", "))))
 	} else {
-		fmt.Println(ui.WarningPanel.Render("⚠️  Some tools are missing. You can still use gospecify, but some AI assistants may not work."))
+		fmt.Println(theme.WarningPanel.Render("⚠️  Some tools are missing. You can still use gospecify, but some AI assistants may not work."))
 		fmt.Println()
 		fmt.Println("💡 To install missing tools:")
 		fmt.Println("   - Git: https://git-scm.com/downloads")
@@ -83,3 +205,23 @@ func checkTool(tool, description string) bool {
 	_, err := exec.LookPath(tool)
 	return err == nil
 }
+
+// probeTool runs a tool with a harmless argument and reports whether it exits cleanly
+func probeTool(tool string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), toolProbeTimeout)
+	defer cancel()
+
+	args, exists := toolProbeArgs[tool]
+	if !exists {
+		args = []string{"--help"}
+	}
+
+	cmd := exec.CommandContext(ctx, tool, args...)
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("timed out after %s", toolProbeTimeout)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+// Package cmd provides the CLI commands for gospecify
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/manifest"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/ui"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// cleanOptions holds the flags of the clean command
+type cleanOptions struct {
+	assistant  string
+	force      bool
+	includeGit bool
+	dryRun     bool
+}
+
+// NewCleanCmd creates the clean command
+func NewCleanCmd() *cobra.Command {
+	var opts cleanOptions
+
+	cmd := &cobra.Command{
+		Use:     "clean",
+		Aliases: []string{"uninstall"},
+		Short:   "Remove the Specify files from the current project",
+		Long: `Remove what gospecify init generated in the current directory: the
+.specify directory and the assistant's command directory.
+
+The assistant is the one given with --ai, otherwise every assistant whose
+command directory exists. Everything to be deleted is listed first and
+you are asked to confirm unless --force is given. The git repository is
+left alone unless --include-git is passed; specs/ and other files you
+wrote are never touched.
+
+Examples:
+  gospecify clean
+  gospecify clean --ai claude --force
+  gospecify clean --dry-run --include-git`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			theme, err := loadTheme(cmd)
+			if err != nil {
+				return err
+			}
+			return runClean(theme, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.assistant, "ai", "",
+		"Only remove this assistant's command directory (default: every assistant found)")
+	cmd.Flags().BoolVar(&opts.force, "force", false,
+		"Delete without asking for confirmation")
+	cmd.Flags().BoolVar(&opts.includeGit, "include-git", false,
+		"Also delete the .git directory, discarding the repository history")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false,
+		"Only list what would be deleted")
+
+	_ = cmd.RegisterFlagCompletionFunc("ai", completeAssistants(false))
+
+	return cmd
+}
+
+// runClean executes the clean command
+func runClean(theme *ui.Theme, opts cleanOptions) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to get current directory", err)
+	}
+
+	if opts.assistant != "" {
+		if _, exists := config.AIAssistants[opts.assistant]; !exists {
+			return errors.NewValidationError(fmt.Sprintf("unknown AI assistant %q (run 'gospecify list' to see the supported ones)", opts.assistant))
+		}
+	}
+
+	targets := cleanTargets(projectPath, opts)
+	if len(targets) == 0 {
+		fmt.Println(theme.InfoPanel.Render(fmt.Sprintf("Nothing to clean in %s", projectPath)))
+		return nil
+	}
+
+	fmt.Println(theme.InfoPanel.Render(fmt.Sprintf("🧹 Cleaning Specify project in %s", projectPath)))
+	fmt.Println()
+	if opts.dryRun {
+		fmt.Println("📋 Would delete:")
+	} else {
+		fmt.Println("📋 Will delete:")
+	}
+	for _, target := range targets {
+		fmt.Printf("   %s\n", target)
+	}
+	fmt.Println()
+
+	if opts.dryRun {
+		return nil
+	}
+
+	if !opts.force {
+		if !ui.StdinIsTerminal() {
+			return errors.NewValidationError("clean needs --force when it cannot prompt (no terminal)")
+		}
+		confirmed, err := ui.Confirm(fmt.Sprintf("Delete these %d paths?", len(targets)))
+		if err != nil {
+			return errors.Wrap(errors.ErrCodeValidationError, "confirmation failed", err)
+		}
+		if !confirmed {
+			return errors.NewCancelled("clean aborted by user")
+		}
+	}
+
+	for _, target := range targets {
+		if err := os.RemoveAll(filepath.Join(projectPath, filepath.FromSlash(target))); err != nil {
+			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to delete "+target, err)
+		}
+		fmt.Printf("🗑️  Deleted %s\n", target)
+		removeEmptyParents(projectPath, target)
+	}
+
+	fmt.Println()
+	fmt.Println(theme.SuccessPanel.Render(fmt.Sprintf("✅ Removed %d paths", len(targets))))
+	return nil
+}
+
+// cleanTargets returns the existing slash-separated paths clean deletes,
+// in the order they are listed: .specify, the assistant directories, then .git
+func cleanTargets(projectPath string, opts cleanOptions) []string {
+	var assistants []string
+	if opts.assistant != "" {
+		assistants = []string{opts.assistant}
+	} else {
+		assistants = config.DetectAssistants(projectPath)
+		// The manifest names the assistant even if its directory was renamed away
+		if manifest.Exists(projectPath) {
+			if recorded, err := manifest.Load(projectPath); err == nil &&
+				recorded.AIAssistant != "" && !slices.Contains(assistants, recorded.AIAssistant) {
+				assistants = append(assistants, recorded.AIAssistant)
+			}
+		}
+	}
+
+	candidates := []string{".specify"}
+	for _, key := range assistants {
+		if assistant, exists := config.AIAssistants[key]; exists {
+			candidates = append(candidates, strings.TrimSuffix(assistant.Directory, "/"))
+		}
+	}
+	if opts.includeGit {
+		candidates = append(candidates, ".git")
+	}
+
+	var targets []string
+	for _, candidate := range candidates {
+		if slices.Contains(targets, candidate) {
+			continue
+		}
+		if _, err := os.Lstat(filepath.Join(projectPath, filepath.FromSlash(candidate))); err == nil {
+			targets = append(targets, candidate)
+		}
+	}
+	return targets
+}
+
+// removeEmptyParents deletes the parent directories of relPath that were
+// left empty, such as .claude after removing .claude/commands
+func removeEmptyParents(projectPath, relPath string) {
+	for dir := path.Dir(relPath); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		// os.Remove refuses directories that still have content
+		if os.Remove(filepath.Join(projectPath, filepath.FromSlash(dir))) != nil {
+			return
+		}
+		fmt.Printf("🗑️  Deleted empty %s\n", dir)
+	}
+}
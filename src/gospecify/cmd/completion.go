@@ -0,0 +1,89 @@
+// Package cmd provides the CLI commands for gospecify
+package cmd
+
+import (
+	"os"
+	"sort"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewCompletionCmd creates the completion command
+func NewCompletionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "completion bash|zsh|fish|powershell",
+		Short: "Generate the shell completion script",
+		Long: `Generate the autocompletion script for gospecify for the given shell.
+Besides commands and flags it completes assistant names for --ai and
+script types for --script.
+
+Examples:
+  source <(gospecify completion bash)
+  gospecify completion zsh > "${fpath[1]}/_gospecify"
+  gospecify completion fish > ~/.config/fish/completions/gospecify.fish
+  gospecify completion powershell | Out-String | Invoke-Expression`,
+		Args:                  cobra.ExactArgs(1),
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+			var err error
+			switch args[0] {
+			case "bash":
+				err = root.GenBashCompletionV2(os.Stdout, true)
+			case "zsh":
+				err = root.GenZshCompletion(os.Stdout)
+			case "fish":
+				err = root.GenFishCompletion(os.Stdout, true)
+			case "powershell":
+				err = root.GenPowerShellCompletionWithDesc(os.Stdout)
+			default:
+				return errors.NewValidationError("unsupported shell: " + args[0] + " (use bash, zsh, fish or powershell)")
+			}
+			if err != nil {
+				return errors.Wrap(errors.ErrCodeFileSystemError, "failed to write completion script", err)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// completeAssistants completes --ai with the assistant keys, described by
+// their names, and "all" when the command accepts it
+func completeAssistants(includeAll bool) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		keys := make([]string, 0, len(config.AIChoices))
+		for key := range config.AIChoices {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		completions := make([]string, 0, len(keys)+1)
+		for _, key := range keys {
+			completions = append(completions, key+"\t"+config.AIChoices[key])
+		}
+		if includeAll {
+			completions = append(completions, config.AIAssistantAll+"\tEvery assistant")
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeScriptTypes completes --script with the script type keys
+func completeScriptTypes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	keys := make([]string, 0, len(config.ScriptTypes))
+	for key := range config.ScriptTypes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	completions := make([]string, 0, len(keys))
+	for _, key := range keys {
+		completions = append(completions, key+"\t"+config.ScriptTypes[key].Name)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
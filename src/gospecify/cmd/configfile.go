@@ -0,0 +1,75 @@
+// Package cmd provides the CLI commands for gospecify
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// loadConfigFile loads the file named by --config, or else the first
+// .gospecify.yaml found in the current or home directory, and returns an
+// empty configuration when there is none
+func loadConfigFile(cmd *cobra.Command) (*config.FileConfig, error) {
+	path, _ := cmd.Flags().GetString("config")
+	if path == "" {
+		path = config.FindConfigFile()
+	}
+	if path == "" {
+		return &config.FileConfig{}, nil
+	}
+	return config.LoadConfigFile(path)
+}
+
+// loadTheme builds the panel theme from the --config file
+func loadTheme(cmd *cobra.Command) (*ui.Theme, error) {
+	fileCfg, err := loadConfigFile(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return ui.NewTheme(fileCfg.UI), nil
+}
+
+// applyConfigFile fills in every init setting whose flag was not passed
+// explicitly on the command line
+func applyConfigFile(cmd *cobra.Command, cfg *config.ProjectConfig, fileCfg *config.FileConfig) {
+	flags := cmd.Flags()
+	setString := func(flag string, target *string, value string) {
+		if value != "" && !flags.Changed(flag) {
+			*target = value
+		}
+	}
+	setBool := func(flag string, target *bool, value *bool) {
+		if value != nil && !flags.Changed(flag) {
+			*target = *value
+		}
+	}
+
+	setString("ai", &cfg.AIAssistant, fileCfg.AI)
+	setString("script", &cfg.ScriptType, fileCfg.Script)
+	setString("template-set", &cfg.TemplateSet, fileCfg.TemplateSet)
+	setString("owner", &cfg.Owner, fileCfg.Owner)
+	setString("proxy", &cfg.Proxy, fileCfg.Proxy)
+	// A .gospecify.yaml in the current directory may come with an untrusted
+	// checkout, so it cannot send the GitHub token to another API host
+	if fileCfg.GitHubAPIURL != "" && !flags.Changed("config") && config.FindConfigFile() == filepath.Join(".", config.DefaultConfigFile) {
+		if !cfg.Quiet && !flags.Changed("github-api-url") {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: ignoring github_api_url from %s; pass --github-api-url or --config to use it\n",
+				config.DefaultConfigFile)
+		}
+	} else {
+		setString("github-api-url", &cfg.GitHubAPIURL, fileCfg.GitHubAPIURL)
+	}
+	setString("mirror", &cfg.Mirror, fileCfg.Mirror)
+	setString("log-level", &cfg.LogLevel, fileCfg.LogLevel)
+	setBool("no-git", &cfg.NoGit, fileCfg.NoGit)
+	setBool("ignore-agent-tools", &cfg.IgnoreTools, fileCfg.IgnoreAgentTools)
+	setBool("skip-tls", &cfg.SkipTLS, fileCfg.SkipTLS)
+	setBool("debug", &cfg.Debug, fileCfg.Debug)
+	setBool("compact-progress", &cfg.CompactProgress, fileCfg.CompactProgress)
+
+	cfg.UI = fileCfg.UI
+}
@@ -0,0 +1,323 @@
+// Package cmd provides the CLI commands for gospecify
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/manifest"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/scaffold"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/templates"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/ui"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewDoctorCmd creates the doctor command
+func NewDoctorCmd() *cobra.Command {
+	var listMissing bool
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose an existing Specify project",
+		Long: `Diagnose the Specify project in the current directory.
+
+This command checks that .specify/templates, .specify/scripts and the
+assistant command folder exist, that every script the commands refer to
+is present, that shell scripts are executable, and that no managed file
+is missing. Each problem comes with a suggested fix, and the command
+exits non-zero if any is found.
+
+Examples:
+  gospecify doctor
+  gospecify doctor --list-missing`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			theme, err := loadTheme(cmd)
+			if err != nil {
+				return err
+			}
+			// Problems are reported above the error; usage would bury them
+			cmd.SilenceUsage = true
+			return runDoctor(theme, listMissing)
+		},
+	}
+
+	cmd.Flags().BoolVar(&listMissing, "list-missing", false,
+		"List managed files that should exist but are absent")
+
+	return cmd
+}
+
+// scriptReference matches the scripts a command refers to: "sh: scripts/bash/x.sh"
+// entries in its frontmatter and literal .specify/scripts/ paths in its body
+var scriptReference = regexp.MustCompile(`(?m)^\s*(sh|ps|fish|nu):\s*(scripts/\S+)|(\.specify/scripts/[A-Za-z0-9_./-]+)`)
+
+// runDoctor executes the doctor command
+func runDoctor(theme *ui.Theme, listMissing bool) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to get current directory", err)
+	}
+
+	if info, err := os.Stat(filepath.Join(projectPath, ".specify")); err != nil || !info.IsDir() {
+		return errors.NewValidationError(
+			fmt.Sprintf("no .specify directory found in %s (run 'gospecify init --here' first)", projectPath))
+	}
+
+	fmt.Println(theme.InfoPanel.Render(fmt.Sprintf("🩺 Diagnosing Specify project in %s", projectPath)))
+	fmt.Println()
+
+	assistants := config.DetectAssistants(projectPath)
+	expectedAssistant := ""
+	var recorded *manifest.Manifest
+	if manifest.Exists(projectPath) {
+		if recorded, err = manifest.Load(projectPath); err == nil {
+			expectedAssistant = recorded.AIAssistant
+		}
+	}
+
+	scriptType := scaffold.DetectScriptType(projectPath)
+	if scriptType == "" {
+		fmt.Println("⚠️  No generated scripts found, assuming sh")
+		scriptType = config.ScriptTypeBash
+	}
+
+	if len(assistants) > 0 {
+		fmt.Printf("🤖 Assistants: %s\n", strings.Join(assistants, ", "))
+	}
+	fmt.Printf("📜 Script type: %s\n", scriptType)
+	fmt.Println()
+
+	tracker := &config.StepTracker{Title: "Checking project structure"}
+	tracker.Add("templates", ".specify/templates directory")
+	tracker.Add("scripts", ".specify/scripts directory")
+	tracker.Add("commands", "Assistant command directory")
+	tracker.Add("references", "Scripts referenced by commands")
+	tracker.Add("executable", "Executable shell scripts")
+	tracker.Add("managed", "Managed files")
+
+	var suggestions []string
+	fail := func(key, detail, suggestion string) {
+		tracker.Error(key, detail)
+		suggestions = append(suggestions, suggestion)
+	}
+
+	for _, dir := range []string{"templates", "scripts"} {
+		if info, err := os.Stat(filepath.Join(projectPath, ".specify", dir)); err != nil || !info.IsDir() {
+			fail(dir, "missing", "Run 'gospecify recover' to restore .specify/"+dir)
+		} else {
+			tracker.Complete(dir, "present")
+		}
+	}
+
+	switch {
+	case expectedAssistant != "" && !slices.Contains(assistants, expectedAssistant):
+		assistant := config.AIAssistants[expectedAssistant]
+		fail("commands", fmt.Sprintf("%s is missing", assistant.Directory),
+			fmt.Sprintf("Run 'gospecify init --here --force --ai %s --commands-only' to reinstall the %s commands", expectedAssistant, assistant.Name))
+	case len(assistants) == 0:
+		fail("commands", "no assistant command directory found",
+			"Run 'gospecify init --here --force --ai <assistant>' to install an assistant's commands")
+	default:
+		tracker.Complete("commands", strings.Join(assistantDirs(assistants), ", "))
+	}
+
+	if len(assistants) == 0 {
+		tracker.Skip("references", "no commands to inspect")
+	} else if missingRefs, err := findMissingScriptReferences(projectPath, assistants, scriptType); err != nil {
+		return err
+	} else if len(missingRefs) > 0 {
+		fail("references", strings.Join(missingRefs, "; "),
+			"Run 'gospecify recover' to restore the scripts, or 'gospecify init --here --force' to regenerate them")
+	} else {
+		tracker.Complete("references", "all present")
+	}
+
+	if runtime.GOOS == "windows" {
+		tracker.Skip("executable", "not applicable on Windows")
+	} else if notExecutable, err := findNonExecutableScripts(projectPath); err != nil {
+		return err
+	} else if len(notExecutable) > 0 {
+		fail("executable", strings.Join(notExecutable, ", "),
+			"Run 'chmod +x "+strings.Join(notExecutable, " ")+"'")
+	} else {
+		tracker.Complete("executable", "ok")
+	}
+
+	var missing []string
+	if recorded == nil && len(assistants) == 0 {
+		tracker.Skip("managed", "no manifest or assistant detected")
+	} else {
+		if missing, err = findMissingFiles(projectPath, recorded, assistants, scriptType); err != nil {
+			return err
+		}
+		if len(missing) > 0 {
+			suggestion := "Run 'gospecify recover' to restore the missing managed files"
+			if !listMissing {
+				suggestion += " ('gospecify doctor --list-missing' lists them)"
+			}
+			fail("managed", fmt.Sprintf("%d missing", len(missing)), suggestion)
+		} else {
+			tracker.Complete("managed", "all present")
+		}
+	}
+
+	fmt.Println(ui.NewLiveProgress(tracker).Render())
+
+	if listMissing && len(missing) > 0 {
+		fmt.Println("📋 Missing managed files:")
+		fmt.Println()
+		for _, path := range missing {
+			fmt.Printf("❌ %s\n", path)
+		}
+		fmt.Println()
+	}
+
+	if len(suggestions) == 0 {
+		fmt.Println(theme.SuccessPanel.Render("🎉 The project structure is consistent!"))
+		return nil
+	}
+
+	found := fmt.Sprintf("%d problems found", len(suggestions))
+	if len(suggestions) == 1 {
+		found = "1 problem found"
+	}
+	fmt.Println(theme.WarningPanel.Render("⚠️  " + found + "."))
+	fmt.Println()
+	fmt.Println("💡 To fix them:")
+	for _, suggestion := range suggestions {
+		fmt.Printf("   %s\n", suggestion)
+	}
+
+	return errors.NewValidationError("project structure check failed: " + found)
+}
+
+// assistantDirs returns the command directories of the assistant keys
+func assistantDirs(keys []string) []string {
+	dirs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		dirs = append(dirs, config.AIAssistants[key].Directory)
+	}
+	return dirs
+}
+
+// findMissingScriptReferences returns a sorted description of every script
+// referenced by the assistants' command files for scriptType that exists
+// neither flattened into .specify/scripts nor in the template layout
+func findMissingScriptReferences(projectPath string, assistants []string, scriptType string) ([]string, error) {
+	referencedBy := make(map[string][]string)
+	for _, key := range assistants {
+		dir := filepath.Join(projectPath, filepath.FromSlash(config.AIAssistants[key].Directory))
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrCodeFileSystemError, "failed to read "+config.AIAssistants[key].Directory, err)
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				return nil, errors.Wrap(errors.ErrCodeFileSystemError, "failed to read "+entry.Name(), err)
+			}
+
+			for _, match := range scriptReference.FindAllStringSubmatch(string(content), -1) {
+				var candidates []string
+				switch {
+				case match[3] != "":
+					candidates = []string{match[3]}
+				case match[1] == scriptType:
+					candidates = []string{".specify/" + match[2], ".specify/scripts/" + path.Base(match[2])}
+				default:
+					continue
+				}
+				if !anyExists(projectPath, candidates) {
+					referencedBy[candidates[len(candidates)-1]] = append(referencedBy[candidates[len(candidates)-1]], entry.Name())
+				}
+			}
+		}
+	}
+
+	missing := make([]string, 0, len(referencedBy))
+	for script, commands := range referencedBy {
+		slices.Sort(commands)
+		missing = append(missing, fmt.Sprintf("%s (used by %s)", script, strings.Join(slices.Compact(commands), ", ")))
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// anyExists reports whether any of the slash-separated project-relative paths exists
+func anyExists(projectPath string, relPaths []string) bool {
+	for _, relPath := range relPaths {
+		if _, err := os.Stat(filepath.Join(projectPath, filepath.FromSlash(relPath))); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// findNonExecutableScripts returns the .specify/scripts shell scripts without an executable bit
+func findNonExecutableScripts(projectPath string) ([]string, error) {
+	shellScripts, err := scaffold.FindShellScripts(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var notExecutable []string
+	for _, relPath := range shellScripts {
+		info, err := os.Stat(filepath.Join(projectPath, filepath.FromSlash(relPath)))
+		if err == nil && info.Mode().Perm()&0111 == 0 {
+			notExecutable = append(notExecutable, relPath)
+		}
+	}
+	return notExecutable, nil
+}
+
+// findMissingFiles returns the sorted managed paths that do not exist in
+// projectPath: those listed in the manifest when there is one, otherwise
+// those init generates for the assistants and script type
+func findMissingFiles(projectPath string, recorded *manifest.Manifest, assistants []string, scriptType string) ([]string, error) {
+	var managed []string
+	if recorded != nil {
+		managed = recorded.Paths()
+	} else {
+		assets, err := scaffold.LoadAssets(templates.EmbeddedSource{SetName: config.DefaultTemplateSet})
+		if err != nil {
+			return nil, err
+		}
+		seen := make(map[string]bool)
+		for _, key := range assistants {
+			assistant := config.AIAssistants[key]
+			expected, err := scaffold.ExpectedProjectFiles(assets, &assistant, scriptType, nil, nil, nil, false)
+			if err != nil {
+				return nil, err
+			}
+			for path := range expected {
+				if !seen[path] {
+					seen[path] = true
+					managed = append(managed, path)
+				}
+			}
+		}
+	}
+
+	var missing []string
+	for _, path := range managed {
+		if _, err := os.Stat(filepath.Join(projectPath, filepath.FromSlash(path))); os.IsNotExist(err) {
+			missing = append(missing, path)
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
@@ -0,0 +1,129 @@
+// Package cmd provides the CLI commands for gospecify
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/archive"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/manifest"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/scaffold"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/templates"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/ui"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewExportCmd creates the export command
+func NewExportCmd() *cobra.Command {
+	var cfg config.ProjectConfig
+	var output, timestamp string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a processed project scaffold to a tarball",
+		Long: `Export the processed templates, assistant commands, and scripts to a
+tar.gz archive instead of creating a project directory.
+
+The archive has the same layout init would produce, including the
+.specify/manifest.json, so it can be unpacked into a project later.
+
+Examples:
+  gospecify export --ai claude --output claude-sh.tar.gz
+  gospecify export --ai gemini --script ps --output gemini-ps.tar.gz`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fileCfg, err := loadConfigFile(cmd)
+			if err != nil {
+				return err
+			}
+			cfg.UI = fileCfg.UI
+			if cfg.CreatedAt, err = config.ResolveTimestamp(timestamp); err != nil {
+				return err
+			}
+			return runExport(&cfg, output)
+		},
+	}
+
+	cmd.Flags().StringVar(&cfg.AIAssistant, "ai", "",
+		"AI assistant to export commands for")
+	cmd.Flags().StringVar(&cfg.ScriptType, "script", config.ScriptTypeBash,
+		"Script type to export: sh, ps, fish or nu")
+	cmd.Flags().StringVar(&cfg.TemplateSet, "template-set", config.DefaultTemplateSet,
+		"Embedded template bundle to export from")
+	cmd.Flags().StringVar(&timestamp, "timestamp", "",
+		"Fixed modification time (RFC 3339 or Unix seconds) for archive entries; defaults to $SOURCE_DATE_EPOCH, then now")
+	cmd.Flags().StringVarP(&output, "output", "o", "",
+		"Path of the tar.gz archive to write")
+	_ = cmd.MarkFlagRequired("ai")
+	_ = cmd.MarkFlagRequired("output")
+	_ = cmd.RegisterFlagCompletionFunc("ai", completeAssistants(false))
+	_ = cmd.RegisterFlagCompletionFunc("script", completeScriptTypes)
+
+	return cmd
+}
+
+// runExport executes the export command
+func runExport(cfg *config.ProjectConfig, output string) error {
+	assistant, exists := config.AIAssistants[cfg.AIAssistant]
+	if !exists {
+		return errors.NewValidationError(fmt.Sprintf("Unknown AI assistant: %s", cfg.AIAssistant))
+	}
+	if _, exists := config.ScriptTypes[cfg.ScriptType]; !exists {
+		return errors.NewValidationError(fmt.Sprintf("Unknown script type: %s", cfg.ScriptType))
+	}
+	if sets := templates.ListTemplateSets(); !slices.Contains(sets, cfg.TemplateSet) {
+		return errors.NewValidationError(
+			fmt.Sprintf("Unknown template set: %s (available: %s)", cfg.TemplateSet, strings.Join(sets, ", ")))
+	}
+
+	assets, err := scaffold.LoadAssets(templates.EmbeddedSource{SetName: cfg.TemplateSet})
+	if err != nil {
+		return err
+	}
+
+	files, err := scaffold.ExpectedProjectFiles(assets, &assistant, cfg.ScriptType, scaffold.ProjectReplacements(cfg), nil, nil, false)
+	if err != nil {
+		return err
+	}
+
+	m := manifest.New(cfg)
+	m.Replacements = scaffold.ProjectReplacements(cfg)
+	entries := make([]archive.Entry, 0, len(files)+1)
+	for relPath, content := range files {
+		mode := os.FileMode(0644)
+		if strings.HasPrefix(relPath, ".specify/scripts/") {
+			mode = 0755
+		}
+		entries = append(entries, archive.Entry{Path: relPath, Content: content, Mode: mode})
+		m.Add(relPath, content)
+	}
+
+	manifestData, err := m.Marshal()
+	if err != nil {
+		return err
+	}
+	entries = append(entries, archive.Entry{Path: manifest.RelativePath, Content: manifestData, Mode: 0644})
+
+	file, err := os.Create(output)
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to create output archive", err)
+	}
+
+	if err := archive.WriteTarGz(file, entries, cfg.CreatedAt); err != nil {
+		_ = file.Close()
+		_ = os.Remove(output)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to close output archive", err)
+	}
+
+	fmt.Println(ui.NewTheme(cfg.UI).SuccessPanel.Render(fmt.Sprintf("📦 Exported %d files for %s (%s) to %s",
+		len(entries), assistant.Name, cfg.ScriptType, output)))
+
+	return nil
+}
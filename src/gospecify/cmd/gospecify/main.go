@@ -2,13 +2,19 @@
 package main
 
 import (
+	stderrors "errors"
 	"os"
 
 	"github.com/jsburckhardt/spec-kit/gospecify/cmd"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
 )
 
 func main() {
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		var e *errors.Error
+		if stderrors.As(err, &e) {
+			os.Exit(e.ExitCode())
+		}
+		os.Exit(errors.ExitGeneral)
 	}
 }
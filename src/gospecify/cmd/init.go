@@ -2,27 +2,37 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
-	"path/filepath"
+	"os/signal"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
-	"github.com/jsburckhardt/spec-kit/gospecify/internal/scripts"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/scaffold"
 	"github.com/jsburckhardt/spec-kit/gospecify/internal/templates"
 	"github.com/jsburckhardt/spec-kit/gospecify/internal/ui"
 	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // NewInitCmd creates the init command
 func NewInitCmd() *cobra.Command {
 	var cfg config.ProjectConfig
+	var timestamp, record, replay string
+	var listTemplates, verbose bool
+	var values []string
+	var progressFD int
 
 	cmd := &cobra.Command{
-		Use:   "init [project-name]",
+		Use:   "init [project-name...]",
 		Short: "Initialize a new Specify project from the latest template",
 		Long: `Initialize a new Specify project from the latest template.
 
@@ -34,474 +44,494 @@ This command will:
 5. Initialize a fresh git repository (if not --no-git and no existing repo)
 6. Optionally set up AI assistant commands
 
+Several project names may be given to scaffold each in its own directory
+with the same assistant and script settings.
+
 Examples:
   gospecify init my-project
   gospecify init my-project --ai claude
+  gospecify init svc-a svc-b svc-c --ai claude --script sh
   gospecify init --here --ai claude
-  gospecify init --here --force`,
+  gospecify init --here --force
+  gospecify init my-project --record session.json
+  gospecify init --replay session.json
+  gospecify init my-project --use-release --timeout 2m
+
+Pressing Ctrl+C, or running past --timeout, aborts the current step and
+removes any partially downloaded files.`,
 		Args: func(cmd *cobra.Command, args []string) error {
+			if listTemplates {
+				return nil
+			}
+			if replay != "" {
+				if len(args) > 0 || cfg.Here {
+					return fmt.Errorf("--replay takes the project names and --here from the session file")
+				}
+				return nil
+			}
 			if cfg.Here && len(args) > 0 {
-				return fmt.Errorf("cannot specify both project name and --here flag")
+				return fmt.Errorf("cannot specify both project name and --here flag (use --name to name the project)")
+			}
+			if !cfg.Here && cmd.Flags().Changed("name") {
+				return fmt.Errorf("--name requires --here; otherwise the project name is the directory to create")
 			}
 			if !cfg.Here && len(args) == 0 {
 				return fmt.Errorf("must specify either a project name or use --here flag")
 			}
+			seen := make(map[string]bool, len(args))
+			for _, name := range args {
+				if seen[name] {
+					return fmt.Errorf("project name %s given more than once", name)
+				}
+				seen[name] = true
+			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) > 0 {
-				cfg.Name = args[0]
+			if listTemplates {
+				return printEmbeddedAssets(cfg.TemplateSet)
+			}
+			fileCfg, err := loadConfigFile(cmd)
+			if err != nil {
+				return err
+			}
+			applyConfigFile(cmd, &cfg, fileCfg)
+			// The arguments parsed, so a failure in quiet mode prints only the error
+			if cfg.Quiet {
+				cmd.SilenceUsage = true
+			}
+			if verbose && !cmd.Flags().Changed("log-level") {
+				cfg.LogLevel = config.LogLevelInfo
+			}
+			if cfg.OutputFormat, err = outputFormat(cmd); err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed("accessible") {
+				cfg.Accessible = config.AccessibleFromEnv()
+			}
+			if apiURL := os.Getenv(config.GitHubAPIURLEnv); apiURL != "" && !cmd.Flags().Changed("github-api-url") {
+				cfg.GitHubAPIURL = apiURL
+			}
+			// The arrow-key menus need a terminal; the accessible prompts read pipes fine
+			if !cfg.Accessible && !ui.StdinIsTerminal() {
+				cfg.NonInteractive = true
+			}
+			if replay != "" {
+				session, err := replaySession(cmd, replay)
+				if err != nil {
+					return err
+				}
+				session.apply(&cfg)
+				timestamp = session.Timestamp
+				args = session.Projects
+			}
+			if cmd.Flags().Changed("set") {
+				if cfg.Values, err = parseSetValues(values); err != nil {
+					return err
+				}
+			}
+			if cmd.Flags().Changed("describe") {
+				cfg.Describe = strings.TrimSpace(cfg.Describe)
+				if cfg.Describe == "" {
+					return errors.NewValidationError("--describe requires a non-empty feature description")
+				}
+			}
+			if cmd.Flags().Changed("commands") {
+				cfg.Commands = normalizeCommandNames(cfg.Commands)
+			}
+			if cfg.CreatedAt, err = config.ResolveTimestamp(timestamp); err != nil {
+				return err
+			}
+			progress, err := progressWriter(progressFD)
+			if err != nil {
+				return err
+			}
+			if cfg.Quiet {
+				// Batch headers and summaries go to progress as well
+				progress = io.Discard
 			}
-			cfg.CreatedAt = time.Now()
-			return runInit(&cfg)
+			ctx, stop := initContext(cfg.Timeout)
+			defer stop()
+			if len(args) > 1 {
+				err = runInitBatch(ctx, &cfg, args, progress)
+			} else {
+				if len(args) == 1 {
+					cfg.Name = args[0]
+				}
+				err = runInit(ctx, &cfg, progress, os.Stdout)
+			}
+			if err != nil || record == "" {
+				return err
+			}
+
+			if err := saveSession(record, newInitSession(&cfg, args, timestamp)); err != nil {
+				return err
+			}
+			if cfg.OutputFormat == config.OutputJSON || cfg.Quiet {
+				return nil
+			}
+			fmt.Printf("📝 Session recorded to %s; repeat it with 'gospecify init --replay %s'\n", record, record)
+			return nil
 		},
 	}
 
 	// Flags
 	cmd.Flags().StringVar(&cfg.AIAssistant, "ai", "",
-		"AI assistant to use: claude, gemini, copilot, cursor, qwen, opencode, windsurf, kilocode, auggie, or roo")
+		"AI assistant to use: claude, gemini, copilot, cursor, qwen, opencode, windsurf, kilocode, auggie, roo, or all")
 	cmd.Flags().StringVar(&cfg.ScriptType, "script", "",
-		"Script type to use: sh or ps")
+		"Script type to use: sh, ps, fish or nu")
 	cmd.Flags().BoolVar(&cfg.IgnoreTools, "ignore-agent-tools", false,
 		"Skip checks for AI agent tools like Claude Code")
 	cmd.Flags().BoolVar(&cfg.NoGit, "no-git", false,
 		"Skip git repository initialization")
 	cmd.Flags().BoolVar(&cfg.Here, "here", false,
 		"Initialize project in the current directory instead of creating a new one")
+	cmd.Flags().StringVar(&cfg.Name, "name", "",
+		"Project name shown with --here (default: the name in package.json, pyproject.toml, go.mod or Cargo.toml, then the directory name)")
 	cmd.Flags().BoolVar(&cfg.Force, "force", false,
 		"Force merge/overwrite when using --here (skip confirmation)")
+	cmd.Flags().BoolVar(&cfg.NonInteractive, "yes", false,
+		"Never prompt: use the default assistant and script type when --ai or --script is missing, and fail where a confirmation would be needed (implied when stdin is not a terminal)")
+	cmd.Flags().BoolVar(&cfg.NonInteractive, "non-interactive", false,
+		"Alias for --yes")
 	cmd.Flags().BoolVar(&cfg.SkipTLS, "skip-tls", false,
 		"Skip SSL/TLS verification (not recommended)")
+	cmd.Flags().StringVar(&cfg.Proxy, "proxy", "",
+		"Proxy URL for GitHub requests and --from clones; overrides HTTPS_PROXY, HTTP_PROXY and NO_PROXY (and their lowercase forms), which are honored otherwise")
+	cmd.Flags().StringVar(&cfg.GitHubAPIURL, "github-api-url", "",
+		"GitHub API base URL, e.g. https://github.example.com/api/v3 for GitHub Enterprise (default "+config.GitHubAPI+", or $"+config.GitHubAPIURLEnv+")")
+	cmd.Flags().StringVar(&cfg.Mirror, "mirror", "",
+		"Base URL to download release assets from instead of https://"+config.GitHubDownloadHost+", keeping the asset path (e.g. https://mirror.example.com/github)")
+	cmd.Flags().BoolVar(&cfg.NoCache, "no-cache", false,
+		"Ask GitHub for the release even when a cached lookup is still fresh (the answer is cached again)")
+	cmd.Flags().DurationVar(&cfg.CacheTTL, "cache-ttl", config.DefaultReleaseCacheTTL,
+		"How long a cached release lookup is reused without asking GitHub; older entries are revalidated with their ETag")
+	cmd.Flags().BoolVarP(&cfg.Quiet, "quiet", "q", false,
+		"Print nothing but errors: no progress, warnings or success panel (cannot be combined with --output json)")
 	cmd.Flags().BoolVar(&cfg.Debug, "debug", false,
-		"Show verbose diagnostic output for network and extraction failures")
+		"Log HTTP requests, extracted paths and git commands to stderr (same as --log-level debug)")
+	cmd.Flags().StringVar(&cfg.LogLevel, "log-level", config.DefaultLogLevel,
+		"Minimum level of the log lines written to stderr: debug, info, warn, or error")
+	cmd.Flags().BoolVar(&verbose, "verbose", false,
+		"Log each step as it runs (same as --log-level info)")
 	cmd.Flags().StringVar(&cfg.GitHubToken, "github-token", "",
-		"GitHub token to use for API requests (or set GH_TOKEN or GITHUB_TOKEN environment variable)")
+		"GitHub token to use for API requests (or set GH_TOKEN or GITHUB_TOKEN, or log in with gh auth login)")
+	cmd.Flags().BoolVar(&cfg.CompactProgress, "compact-progress", false,
+		"Collapse completed steps into a summary line and only expand the running step")
+	cmd.Flags().StringVar(&cfg.Branch, "branch", config.DefaultBranch,
+		"Initial branch of the new git repository")
+	cmd.Flags().StringVar(&cfg.GitCommitMessage, "git-commit-message", "",
+		"Message for the initial git commit (default \""+config.DefaultCommitMessage+"\")")
+	cmd.Flags().StringVar(&cfg.GitAuthor, "git-author", "",
+		"Author of the initial git commit as \"Name <email>\"; defaults to your git config")
+	cmd.Flags().BoolVar(&cfg.Commit, "commit", false,
+		"In an existing git repository, commit the generated files (skipping any .gitignore excludes)")
+	cmd.Flags().BoolVar(&cfg.Gitignore, "gitignore", false,
+		"Add the assistant's agent folder (e.g. .claude/), which may hold credentials, to .gitignore before the initial commit")
+	cmd.Flags().BoolVar(&cfg.NoGitChmod, "no-git-chmod", false,
+		"Do not record the executable bit of the .sh, .fish and .nu scripts in .specify/scripts in the git index for the initial commit")
+	// Every generated directory always gets files, so there is nothing left to opt out of
+	cmd.Flags().Bool("no-gitkeep", false, "No longer has any effect")
+	_ = cmd.Flags().MarkDeprecated("no-gitkeep", "init never leaves a generated directory empty")
+	cmd.Flags().StringVar(&cfg.TemplateSet, "template-set", config.DefaultTemplateSet,
+		"Embedded template bundle to scaffold from")
+	cmd.Flags().BoolVar(&cfg.CleanBefore, "clean-before", false,
+		"Remove files recorded in the project manifest before re-scaffolding")
+	cmd.Flags().StringVar(&cfg.Describe, "describe", "",
+		"One-line feature description used to seed specs/001-initial/spec.md")
+	cmd.Flags().BoolVar(&cfg.CopyScriptsToAgent, "copy-scripts-to-agent", false,
+		"Also copy generated scripts into a scripts/ folder inside the assistant directory")
+	cmd.Flags().BoolVar(&cfg.KeepTemplateStructure, "keep-template-structure", false,
+		"Write scripts using the template archive layout (.specify/scripts/bash/...) instead of flattening them")
+	cmd.Flags().StringVar(&cfg.Owner, "owner", "",
+		"Owner or team substituted for {{owner}} in generated files")
+	cmd.Flags().StringArrayVar(&values, "set", nil,
+		"Substitute value for {{key}} in generated files, as key=value (repeatable)")
+	cmd.Flags().StringVar(&cfg.From, "from", "",
+		"Git URL of a repository whose templates/ and scripts/ directories replace the embedded assets")
+	cmd.Flags().StringVar(&cfg.Ref, "ref", "",
+		"Branch or tag to clone with --from")
+	cmd.Flags().StringVar(&cfg.TemplateDir, "template-dir", "",
+		"Local directory whose assets/templates and assets/scripts replace the embedded assets, for authoring templates without rebuilding")
+	cmd.Flags().StringVar(&cfg.TemplateRepo, "template-repo", "",
+		"GitHub repository (owner/name) whose latest release provides the template archive")
+	cmd.Flags().StringVar(&cfg.TemplateRef, "template-ref", "",
+		"Release tag to use with --template-repo instead of the latest release")
+	cmd.Flags().BoolVar(&cfg.UseRelease, "use-release", false,
+		"Download the templates from the latest spec-kit release instead of using the embedded ones, falling back to them when GitHub is unreachable")
+	cmd.Flags().BoolVar(&cfg.UseRelease, "force-download", false,
+		"Alias for --use-release")
+	cmd.Flags().BoolVar(&cfg.AllowSymlinks, "allow-symlinks", false,
+		"Extract symlinks from the downloaded template archive when they point inside it, instead of refusing the archive")
+	cmd.Flags().DurationVar(&cfg.Timeout, "timeout", 0,
+		"Abort init if it has not finished within this duration (e.g. 90s or 5m); 0 means no limit")
+	cmd.Flags().StringVar(&timestamp, "timestamp", "",
+		"Fixed creation time (RFC 3339 or Unix seconds) for reproducible output; defaults to $SOURCE_DATE_EPOCH, then now")
+	cmd.Flags().IntVar(&cfg.WriteConcurrency, "write-concurrency", config.DefaultWriteConcurrency,
+		"Number of template files to write in parallel (helps on network filesystems)")
+	cmd.Flags().BoolVar(&cfg.WithEditorConfig, "with-editor-config", false,
+		"Write .vscode/extensions.json recommending the GitHub Copilot extensions when Copilot is selected")
+	cmd.Flags().StringVar(&cfg.RetryStep, "retry-step", "",
+		"Resume a failed init from this step (e.g. git), reusing the steps that already completed")
+	cmd.Flags().BoolVar(&cfg.RollbackOnError, "rollback-on-error", false,
+		"On failure, remove the project directory init created, or with --here every file and directory this run created, instead of saving progress for --retry-step")
+	cmd.Flags().BoolVar(&cfg.FixLineEndings, "fix-line-endings", false,
+		"Normalize templates that mix CRLF and LF line endings to LF before processing")
+	cmd.Flags().BoolVar(&cfg.ShowTree, "tree", false,
+		"Show the generated files as a tree in the success output")
+	cmd.Flags().BoolVar(&cfg.DryRun, "dry-run", false,
+		"Print the directories, files and git actions init would create or overwrite without changing anything")
+	cmd.Flags().StringVar(&cfg.TempDir, "temp-dir", "",
+		"Directory for temporary files such as --from clones (defaults to the system temp directory)")
+	cmd.Flags().BoolVar(&cfg.Verify, "verify", false,
+		"Re-read every written file after init and fail if any content or executable bit does not match")
+	cmd.Flags().BoolVar(&cfg.CommandsOnly, "commands-only", false,
+		"Only (re)install the assistant command files, leaving .specify templates and scripts untouched (requires --here)")
+	cmd.Flags().StringSliceVar(&cfg.Commands, "commands", nil,
+		"Only install these commands into the assistant directory, e.g. specify,plan (all are still written to .specify/templates/commands)")
+	cmd.Flags().BoolVar(&cfg.Accessible, "accessible", false,
+		fmt.Sprintf("Screen-reader friendly output: one plain line per step and numbered prompts instead of menus (or set %s=1)", config.AccessibleEnv))
+	cmd.Flags().IntVar(&progressFD, "progress-fd", 2,
+		"File descriptor progress and status panels are written to (1 for stdout, 2 for stderr, or an inherited descriptor)")
+	cmd.Flags().StringVar(&record, "record", "",
+		"After a successful run, save every resolved choice (including interactive selections) to this session file")
+	cmd.Flags().StringVar(&replay, "replay", "",
+		"Re-run init non-interactively with the choices saved by --record")
+	// Debugging aid: shows what the embedded filesystem holds without scaffolding anything
+	cmd.Flags().BoolVar(&listTemplates, "list-templates", false,
+		"Debugging aid: print every embedded template and script with its size, then exit")
+	_ = cmd.Flags().MarkHidden("list-templates")
+
+	_ = cmd.RegisterFlagCompletionFunc("ai", completeAssistants(true))
+	_ = cmd.RegisterFlagCompletionFunc("script", completeScriptTypes)
+
+	cmd.MarkFlagsMutuallyExclusive("record", "replay")
+	cmd.MarkFlagsMutuallyExclusive("record", "dry-run")
+	cmd.MarkFlagsMutuallyExclusive("from", "template-repo")
+	cmd.MarkFlagsMutuallyExclusive("template-dir", "from")
+	cmd.MarkFlagsMutuallyExclusive("template-dir", "template-repo")
+	cmd.MarkFlagsMutuallyExclusive("template-dir", "use-release")
+	cmd.MarkFlagsMutuallyExclusive("template-dir", "force-download")
+	cmd.MarkFlagsMutuallyExclusive("from", "use-release")
+	cmd.MarkFlagsMutuallyExclusive("from", "force-download")
 
 	return cmd
 }
 
-// runInit executes the init command
-func runInit(cfg *config.ProjectConfig) error {
+// replaySession loads the --replay session file, rejecting flags that would
+// change the recorded choices
+func replaySession(cmd *cobra.Command, path string) (*initSession, error) {
+	// Flags that only affect presentation or credentials may still be given
+	allowed := map[string]bool{"replay": true, "github-token": true, "accessible": true, "progress-fd": true, "timeout": true, "quiet": true}
 
-	// Initialize progress tracker
-	tracker := &config.StepTracker{
-		Title: "Initializing Specify Project",
-	}
-	tracker.Add("validate", "Validate configuration")
-	tracker.Add("assistant", "Select AI assistant")
-	tracker.Add("script", "Select script type")
-	tracker.Add("tools", "Check required tools")
-	tracker.Add("download", "Prepare project directory")
-	tracker.Add("extract", "Setup embedded assets")
-	tracker.Add("process", "Process templates")
-	tracker.Add("scripts", "Generate scripts")
-	tracker.Add("git", "Initialize git repository")
-
-	// Set up live progress display
-	progress := ui.NewLiveProgress(tracker)
-	fmt.Println(progress.Render())
-
-	// Step 1: Validate configuration
-	tracker.Start("validate", "")
-	if err := validateConfig(cfg); err != nil {
-		tracker.Error("validate", err.Error())
-		return err
+	var overrides []string
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if !allowed[flag.Name] {
+			overrides = append(overrides, "--"+flag.Name)
+		}
+	})
+	if len(overrides) > 0 {
+		return nil, errors.NewValidationError(fmt.Sprintf(
+			"--replay re-runs the recorded choices exactly and cannot be combined with %s", strings.Join(overrides, ", ")))
 	}
-	tracker.Complete("validate", "Configuration valid")
+	return loadSession(path)
+}
 
-	// Step 2: Select AI assistant
-	tracker.Start("assistant", "")
-	assistant, err := selectAssistant(cfg)
-	if err != nil {
-		tracker.Error("assistant", err.Error())
-		return err
+// progressWriter returns the stream progress is rendered to: stdout for 1,
+// stderr for 2, or an already-open descriptor inherited from the parent process
+func progressWriter(fd int) (io.Writer, error) {
+	switch fd {
+	case 1:
+		return os.Stdout, nil
+	case 2:
+		return os.Stderr, nil
 	}
-	cfg.AIAssistant = assistant.Key
-	tracker.Complete("assistant", fmt.Sprintf("Selected %s", assistant.Name))
-
-	// Step 3: Select script type
-	tracker.Start("script", "")
-	scriptType, err := selectScriptType(cfg)
-	if err != nil {
-		tracker.Error("script", err.Error())
-		return err
+	if fd < 1 {
+		return nil, errors.NewValidationError(fmt.Sprintf("--progress-fd %d is not a writable file descriptor", fd))
 	}
-	cfg.ScriptType = scriptType
-	tracker.Complete("script", fmt.Sprintf("Selected %s", config.ScriptTypes[scriptType].Name))
 
-	// Step 4: Check required tools
-	tracker.Start("tools", "")
-	if err := checkRequiredTools(assistant, cfg.IgnoreTools); err != nil {
-		tracker.Error("tools", err.Error())
-		return err
+	file := os.NewFile(uintptr(fd), fmt.Sprintf("progress-fd-%d", fd))
+	if _, err := file.Stat(); err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("--progress-fd %d is not an open file descriptor", fd))
 	}
-	tracker.Complete("tools", "All tools available")
-
-	// Step 5: Prepare project directory (using embedded assets)
-	tracker.Start("download", "")
-	projectPath, err := prepareProjectDirectory(cfg)
-	if err != nil {
-		tracker.Error("download", err.Error())
-		return err
-	}
-	cfg.Path = projectPath
-	tracker.Complete("download", "Project directory prepared")
-
-	// Step 6: Skip extract (using embedded assets only)
-	tracker.Start("extract", "")
-	tracker.Complete("extract", "Using embedded assets")
+	return file, nil
+}
 
-	// Step 7: Process templates
-	tracker.Start("process", "")
-	if err := processTemplates(projectPath, assistant, scriptType); err != nil {
-		tracker.Error("process", err.Error())
-		return err
+// initContext returns the context init runs under. It is cancelled by the
+// first Ctrl+C, after which a second one kills the process as usual, and by
+// the timeout when it is positive.
+func initContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	if timeout <= 0 {
+		return ctx, stop
+	}
+
+	ctx, cancel := context.WithTimeoutCause(ctx, timeout,
+		errors.NewCancelled(fmt.Sprintf("init timed out after %s", timeout)))
+	return ctx, func() {
+		cancel()
+		stop()
 	}
-	tracker.Complete("process", "Templates processed")
+}
 
-	// Step 8: Generate scripts
-	tracker.Start("scripts", "")
-	if err := generateScripts(projectPath, assistant, scriptType); err != nil {
-		tracker.Error("scripts", err.Error())
+// runInit executes the init command, rendering progress to out
+func runInit(ctx context.Context, cfg *config.ProjectConfig, out, stdout io.Writer) error {
+	// The JSON document goes to stdout so --progress-fd cannot split it from the result
+	renderer, err := ui.NewRenderer(rendererName(cfg), ui.RendererOptions{
+		Out:     out,
+		Stdout:  stdout,
+		Compact: cfg.CompactProgress,
+		Theme:   ui.NewTheme(cfg.UI),
+	})
+	if err != nil {
 		return err
 	}
-	tracker.Complete("scripts", "Scripts generated")
 
-	// Step 9: Initialize git repository
-	tracker.Start("git", "")
-	if err := initializeGit(projectPath, cfg.NoGit); err != nil {
-		tracker.Error("git", err.Error())
+	result, err := scaffold.Run(ctx, cfg, renderer)
+	if err != nil {
+		renderer.Error(err)
 		return err
 	}
-	if !cfg.NoGit {
-		tracker.Complete("git", "Git repository initialized")
-	} else {
-		tracker.Skip("git", "Skipped")
-	}
 
 	// Show success message and next steps
-	if err := showSuccessMessage(cfg, assistant); err != nil {
-		return err
-	}
+	renderer.Success(result)
 
 	return nil
 }
 
-// validateConfig validates the initial configuration
-func validateConfig(cfg *config.ProjectConfig) error {
-	if cfg.Here {
-		cwd, err := os.Getwd()
-		if err != nil {
-			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to get current directory", err)
-		}
-		cfg.Path = cwd
-		cfg.Name = filepath.Base(cwd)
-	} else {
-		var err error
-		cfg.Path, err = filepath.Abs(cfg.Name)
-		if err != nil {
-			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to resolve project path", err)
-		}
-	}
-
-	// Check if directory exists - only relevant when creating new project directory
-	if !cfg.Here {
-		// When not using --here, we're creating a new directory that shouldn't exist
-		if _, err := os.Stat(cfg.Path); err == nil {
-			return errors.NewValidationError(
-				fmt.Sprintf("Directory %s already exists", cfg.Path))
-		}
-	}
-	// When using --here, the current directory should exist and we don't need to check
-
-	return nil
+// rendererName picks the registered renderer for the output settings of cfg
+func rendererName(cfg *config.ProjectConfig) string {
+	switch {
+	case cfg.Quiet:
+		return ui.RendererQuiet
+	case cfg.OutputFormat == config.OutputJSON:
+		return ui.RendererJSON
+	case cfg.Accessible:
+		return ui.RendererAccessible
+	}
+	return ui.RendererHuman
 }
 
-// selectAssistant selects the AI assistant to use
-func selectAssistant(cfg *config.ProjectConfig) (*config.AIAssistant, error) {
-	if cfg.AIAssistant != "" {
-		assistant, exists := config.AIAssistants[cfg.AIAssistant]
-		if !exists {
-			return nil, errors.NewValidationError(
-				fmt.Sprintf("Unknown AI assistant: %s", cfg.AIAssistant))
+// runInitBatch initializes each named project in turn with shared settings,
+// continuing past failures and summarizing them at the end. With --output
+// json the summary is a single array holding each project's document.
+func runInitBatch(ctx context.Context, cfg *config.ProjectConfig, names []string, out io.Writer) error {
+	var succeeded, failed []string
+	var firstErr error
+
+	// Headers and the summary panel would break the JSON document on stdout
+	jsonOutput := cfg.OutputFormat == config.OutputJSON
+	documents := []json.RawMessage{}
+	if jsonOutput {
+		out = io.Discard
+		defer func() {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			_ = encoder.Encode(documents)
+		}()
+	}
+
+	for _, name := range names {
+		projectCfg := *cfg
+		projectCfg.Name = name
+
+		var document bytes.Buffer
+		stdout := io.Writer(os.Stdout)
+		if jsonOutput {
+			stdout = &document
 		}
-		return &assistant, nil
-	}
-
-	// Interactive selection
-	selector := ui.NewSelector("Select your AI assistant", config.AIChoices, "claude")
-	selected, err := selector.Run()
-	if err != nil {
-		return nil, errors.Wrap(errors.ErrCodeValidationError, "assistant selection failed", err)
-	}
-
-	assistant := config.AIAssistants[selected]
-	return &assistant, nil
-}
 
-// selectScriptType selects the script type to use
-func selectScriptType(cfg *config.ProjectConfig) (string, error) {
-	if cfg.ScriptType != "" {
-		if _, exists := config.ScriptTypes[cfg.ScriptType]; !exists {
-			return "", errors.NewValidationError(
-				fmt.Sprintf("Unknown script type: %s", cfg.ScriptType))
+		_, _ = fmt.Fprintln(out, ui.BoldStyle.Render(fmt.Sprintf("▶ %s", name)))
+		err := runInit(ctx, &projectCfg, out, stdout)
+		if document.Len() > 0 {
+			documents = append(documents, json.RawMessage(document.Bytes()))
 		}
-		return cfg.ScriptType, nil
-	}
+		if err != nil {
+			_, _ = fmt.Fprintln(out, ui.RedStyle.Render(fmt.Sprintf("❌ %s: %v", name, err)))
+			failed = append(failed, name)
+			if firstErr == nil {
+				firstErr = err
+			}
+			// A cancelled prompt, Ctrl+C or timeout means the user wants out, not the next project
+			if errors.CodeOf(err) == errors.ErrCodeCancelled {
+				break
+			}
+			continue
+		}
+		succeeded = append(succeeded, name)
 
-	// Interactive selection
-	scriptChoices := make(map[string]string)
-	for key, scriptType := range config.ScriptTypes {
-		scriptChoices[key] = scriptType.Name
+		// Reuse the first project's selections so later ones don't prompt again
+		cfg.AIAssistant = projectCfg.AIAssistant
+		cfg.ScriptType = projectCfg.ScriptType
+		_, _ = fmt.Fprintln(out)
 	}
 
-	selector := ui.NewSelector("Select your script type", scriptChoices, "sh")
-	selected, err := selector.Run()
-	if err != nil {
-		return "", errors.Wrap(errors.ErrCodeValidationError, "script type selection failed", err)
+	theme := ui.NewTheme(cfg.UI)
+	summary := fmt.Sprintf("Initialized %d of %d projects", len(succeeded), len(names))
+	if len(succeeded) > 0 {
+		summary += "\n✅ " + strings.Join(succeeded, ", ")
 	}
-
-	return selected, nil
-}
-
-// checkRequiredTools checks that required tools are available
-func checkRequiredTools(assistant *config.AIAssistant, ignoreTools bool) error {
-	if ignoreTools {
+	if len(failed) == 0 {
+		_, _ = fmt.Fprintln(out, theme.SuccessPanel.Render(summary))
 		return nil
 	}
 
-	// Check for git (optional)
-	if _, err := exec.LookPath("git"); err != nil {
-		fmt.Println("Warning: git not found. Consider installing git for version control.")
-	}
-
-	// Check for AI assistant CLI tool (if required)
-	if assistant.CLITool != "" {
-		if _, err := exec.LookPath(assistant.CLITool); err != nil {
-			return errors.NewToolNotFound(assistant.CLITool)
-		}
-	}
-
-	return nil
+	summary += "\n❌ " + strings.Join(failed, ", ")
+	_, _ = fmt.Fprintln(out, theme.WarningPanel.Render(summary))
+	return errors.Wrap(errors.CodeOf(firstErr),
+		fmt.Sprintf("%d of %d projects failed to initialize (%s)", len(failed), len(names), strings.Join(failed, ", ")), firstErr)
 }
 
-// processTemplates processes templates from embedded assets and creates project structure
-func processTemplates(projectPath string, assistant *config.AIAssistant, scriptType string) error {
-	// Load embedded assets
-	assets, err := templates.LoadEmbeddedAssets()
-	if err != nil {
-		return errors.Wrap(errors.ErrCodeAssetNotFound, "failed to load embedded assets", err)
-	}
-
-	// Create template processor
-	processor := templates.NewProcessor(assets, assistant, scriptType)
-
-	// Create base project structure
-	dirs := []string{
-		".specify/templates",
-		".specify/templates/commands",
-		assistant.Directory,
-	}
-
-	for _, dir := range dirs {
-		dirPath := filepath.Join(projectPath, dir)
-		if err := os.MkdirAll(dirPath, 0755); err != nil {
-			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to create directory", err)
-		}
-	}
-
-	// Process and write all templates to project directory
-	processedTemplates, err := processor.ProcessAllTemplates()
+// outputFormat reads and checks the global --output flag
+func outputFormat(cmd *cobra.Command) (string, error) {
+	format, err := cmd.Flags().GetString("output")
 	if err != nil {
-		return err
-	}
-
-	for templateName, content := range processedTemplates {
-		templatePath := filepath.Join(projectPath, ".specify", "templates", templateName)
-		if err := os.MkdirAll(filepath.Dir(templatePath), 0755); err != nil {
-			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to create template directory", err)
-		}
-
-		if err := os.WriteFile(templatePath, content, 0644); err != nil {
-			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to write template", err)
-		}
+		return "", errors.Wrap(errors.ErrCodeValidationError, "failed to read --output", err)
 	}
-
-	// Copy command templates to assistant folder
-	for templateName, content := range processedTemplates {
-		if strings.HasPrefix(templateName, "commands/") {
-			commandName := strings.TrimPrefix(templateName, "commands/")
-			finalCommandName := generateCommandFileName(commandName, assistant)
-			commandPath := filepath.Join(projectPath, assistant.Directory, finalCommandName)
-
-			if err := os.WriteFile(commandPath, content, 0644); err != nil {
-				return errors.Wrap(errors.ErrCodeFileSystemError, "failed to write command template", err)
-			}
-		}
+	switch format {
+	case config.OutputText, config.OutputJSON:
+		return format, nil
+	default:
+		return "", errors.NewValidationError(fmt.Sprintf("--output must be %s or %s, not %q", config.OutputText, config.OutputJSON, format))
 	}
-
-	return nil
 }
 
-// generateScripts generates the setup scripts
-func generateScripts(projectPath string, assistant *config.AIAssistant, scriptType string) error {
-	// Load embedded assets
-	assets, err := templates.LoadEmbeddedAssets()
-	if err != nil {
-		return errors.Wrap(errors.ErrCodeAssetNotFound, "failed to load embedded assets", err)
-	}
+// setKeyPattern matches the names --set accepts, the same ones {{!required:name}} does
+var setKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
 
-	// Create script generator
-	generator := scripts.NewGenerator(assets, assistant, scriptType)
-
-	// Generate all scripts
-	generatedScripts, err := generator.GenerateAllScripts()
-	if err != nil {
-		return err
+// parseSetValues parses repeated --set key=value flags into a map
+func parseSetValues(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
 	}
-
-	// Write scripts to project directory
-	scriptsDir := filepath.Join(projectPath, ".specify", "scripts")
-	for scriptName, content := range generatedScripts {
-		scriptPath := filepath.Join(scriptsDir, scriptName+scripts.GetScriptExtension(scriptType))
-		if err := os.MkdirAll(scriptsDir, 0755); err != nil {
-			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to create scripts directory", err)
+	parsed := make(map[string]string, len(values))
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, "=")
+		if !ok {
+			return nil, errors.NewValidationError(fmt.Sprintf("--set %q must have the form key=value", value))
 		}
-
-		if err := os.WriteFile(scriptPath, content, 0755); err != nil {
-			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to write script", err)
+		if !setKeyPattern.MatchString(key) && !slices.Contains(templates.ReservedPlaceholders, key) {
+			return nil, errors.NewValidationError(
+				fmt.Sprintf("--set key %q may only contain letters, digits, '_', '.' and '-'", key))
 		}
+		parsed[key] = val
 	}
-
-	return nil
+	return parsed, nil
 }
 
-// initializeGit initializes a git repository if requested
-func initializeGit(projectPath string, noGit bool) error {
-	if noGit {
-		return nil
-	}
-
-	// Check if already a git repository
-	if _, err := os.Stat(filepath.Join(projectPath, ".git")); err == nil {
-		return nil // Already a git repo
-	}
-
-	// Initialize git repository
-	cmd := exec.Command("git", "init")
-	cmd.Dir = projectPath
-	if err := cmd.Run(); err != nil {
-		return errors.Wrap(errors.ErrCodeGitError, "failed to initialize git repository", err)
-	}
-
-	// Create initial commit
-	cmd = exec.Command("git", "add", ".")
-	cmd.Dir = projectPath
-	if err := cmd.Run(); err != nil {
-		return errors.Wrap(errors.ErrCodeGitError, "failed to add files to git", err)
-	}
-
-	cmd = exec.Command("git", "commit", "-m", "Initial commit - Specify project setup")
-	cmd.Dir = projectPath
-	if err := cmd.Run(); err != nil {
-		return errors.Wrap(errors.ErrCodeGitError, "failed to create initial commit", err)
-	}
-
-	return nil
-}
-
-// showSuccessMessage displays success message and next steps
-func showSuccessMessage(cfg *config.ProjectConfig, assistant *config.AIAssistant) error {
-	fmt.Println()
-	fmt.Println(ui.InfoPanel.Render(fmt.Sprintf("✅ Successfully initialized Specify project in %s", cfg.Path)))
-	fmt.Println()
-
-	// Show security notice
-	if folder, exists := config.AgentFolderMap[assistant.Key]; exists {
-		securityMessage := fmt.Sprintf(
-			"Some agents may store credentials, auth tokens, or other identifying and private artifacts in the agent folder within your project.\nConsider adding %s (or parts of it) to %s to prevent accidental credential leakage.",
-			ui.CyanStyle.Render(folder),
-			ui.CyanStyle.Render(".gitignore"))
-		fmt.Println(ui.WarningPanel.Render(securityMessage))
-		fmt.Println()
-	}
-
-	// Show next steps
-	steps := []string{
-		fmt.Sprintf("1. Go to the project folder: %s", ui.CyanStyle.Render(fmt.Sprintf("cd %s", cfg.Name))),
-		"2. Start using slash commands with your AI agent:",
-		fmt.Sprintf("   - %s - Analyze codebase and requirements", ui.CyanStyle.Render("/analyze")),
-		fmt.Sprintf("   - %s - Get clarification on requirements", ui.CyanStyle.Render("/clarify")),
-		fmt.Sprintf("   - %s - Implement features from specifications", ui.CyanStyle.Render("/implement")),
-		fmt.Sprintf("   - %s - Create development plans", ui.CyanStyle.Render("/plan")),
-		fmt.Sprintf("   - %s - Generate detailed specifications", ui.CyanStyle.Render("/specify")),
-		fmt.Sprintf("   - %s - Break down work into tasks", ui.CyanStyle.Render("/tasks")),
-	}
-
-	fmt.Println(ui.SuccessPanel.Render(strings.Join(steps, "\n")))
-
-	return nil
-}
-
-// prepareProjectDirectory creates the project directory structure without GitHub download
-func prepareProjectDirectory(cfg *config.ProjectConfig) (string, error) {
-	var projectPath string
-
-	if cfg.Here {
-		// Use current directory
-		cwd, err := os.Getwd()
-		if err != nil {
-			return "", errors.Wrap(errors.ErrCodeFileSystemError, "failed to get current directory", err)
-		}
-		projectPath = cwd
-
-		// Check if directory is empty or force flag is set
-		entries, err := os.ReadDir(projectPath)
-		if err != nil {
-			return "", errors.Wrap(errors.ErrCodeFileSystemError, "failed to read current directory", err)
+// normalizeCommandNames trims the --commands values and any leading slash
+// (as in /plan), dropping empty and repeated names
+func normalizeCommandNames(names []string) []string {
+	var normalized []string
+	for _, name := range names {
+		name = strings.TrimPrefix(strings.TrimSpace(name), "/")
+		if name != "" && !slices.Contains(normalized, name) {
+			normalized = append(normalized, name)
 		}
-
-		if len(entries) > 0 && !cfg.Force {
-			return "", errors.New(errors.ErrCodeValidationError, "directory is not empty (use --force to override)")
-		}
-	} else {
-		// Create new project directory
-		projectPath = filepath.Join(".", cfg.Name)
-
-		// Check if directory already exists
-		if _, err := os.Stat(projectPath); err == nil {
-			return "", errors.New(errors.ErrCodeValidationError, fmt.Sprintf("Directory %s already exists", projectPath))
-		}
-
-		// Create the project directory
-		if err := os.MkdirAll(projectPath, 0755); err != nil {
-			return "", errors.Wrap(errors.ErrCodeFileSystemError, "failed to create project directory", err)
-		}
-	}
-
-	// Convert to absolute path
-	absPath, err := filepath.Abs(projectPath)
-	if err != nil {
-		return "", errors.Wrap(errors.ErrCodeFileSystemError, "failed to get absolute path", err)
-	}
-
-	return absPath, nil
-}
-
-// generateCommandFileName generates the correct filename for a command template based on the assistant's format
-func generateCommandFileName(originalName string, assistant *config.AIAssistant) string {
-	// Extract base name without extension
-	baseName := strings.TrimSuffix(originalName, filepath.Ext(originalName))
-
-	// Apply assistant-specific format
-	switch assistant.Format {
-	case config.FormatPrompt:
-		return baseName + ".prompt.md"
-	case config.FormatTOML:
-		return baseName + ".toml"
-	case config.FormatMarkdown:
-		return baseName + ".md"
-	default:
-		return originalName // fallback to original name
 	}
+	return normalized
 }
@@ -0,0 +1,139 @@
+// Package cmd provides the CLI commands for gospecify
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/templates"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// listScriptType is a script type in the JSON printed by list --json
+type listScriptType struct {
+	Key       string `json:"key"`
+	Name      string `json:"name"`
+	Extension string `json:"extension"`
+	Platform  string `json:"platform"`
+}
+
+// listInfo is the JSON document printed by list --json
+type listInfo struct {
+	Assistants  []config.AIAssistant `json:"assistants"`
+	ScriptTypes []listScriptType     `json:"script_types"`
+}
+
+// NewListCmd creates the list command
+func NewListCmd() *cobra.Command {
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List supported AI assistants and script types",
+		Long: `List the AI assistants accepted by --ai and the script types accepted
+by --script, sorted by key.
+
+Use --json for output that tools can parse reliably.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info := collectListInfo()
+			if asJSON {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(info); err != nil {
+					return errors.Wrap(errors.ErrCodeFileSystemError, "failed to write list", err)
+				}
+				return nil
+			}
+			return printListInfo(info)
+		},
+	}
+
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the assistants and script types as JSON")
+
+	return cmd
+}
+
+// collectListInfo gathers the assistants and script types sorted by key
+func collectListInfo() listInfo {
+	var info listInfo
+	for _, assistant := range config.AIAssistants {
+		info.Assistants = append(info.Assistants, assistant)
+	}
+	sort.Slice(info.Assistants, func(i, j int) bool { return info.Assistants[i].Key < info.Assistants[j].Key })
+
+	for _, scriptType := range config.ScriptTypes {
+		info.ScriptTypes = append(info.ScriptTypes, listScriptType{
+			Key:       scriptType.Key,
+			Name:      scriptType.Name,
+			Extension: scriptType.Extension,
+			Platform:  scriptType.Platform,
+		})
+	}
+	sort.Slice(info.ScriptTypes, func(i, j int) bool { return info.ScriptTypes[i].Key < info.ScriptTypes[j].Key })
+
+	return info
+}
+
+// printListInfo prints the assistants and script types as aligned tables
+func printListInfo(info listInfo) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(w, "AI ASSISTANT\tNAME\tDIRECTORY\tFORMAT\tIDE-BASED")
+	for _, assistant := range info.Assistants {
+		ideBased := "no"
+		if assistant.IsIDEBased {
+			ideBased = "yes"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			assistant.Key, assistant.Name, assistant.Directory, assistant.Format, ideBased)
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "SCRIPT TYPE\tNAME\tEXTENSION\tPLATFORM")
+	for _, scriptType := range info.ScriptTypes {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			scriptType.Key, scriptType.Name, scriptType.Extension, scriptType.Platform)
+	}
+
+	if err := w.Flush(); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to write list", err)
+	}
+	return nil
+}
+
+// printEmbeddedAssets prints every template and script in an embedded
+// template set with its size, for init --list-templates
+func printEmbeddedAssets(setName string) error {
+	assets, err := templates.LoadEmbeddedAssetSet(setName)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ASSET\tBYTES")
+
+	names := assets.ListTemplates()
+	sort.Strings(names)
+	for _, name := range names {
+		content, _ := assets.GetTemplate(name)
+		_, _ = fmt.Fprintf(w, "templates/%s\t%d\n", name, len(content))
+	}
+
+	names = assets.ListScripts()
+	sort.Strings(names)
+	for _, name := range names {
+		content, _ := assets.GetScript(name)
+		_, _ = fmt.Fprintf(w, "scripts/%s\t%d\n", name, len(content))
+	}
+
+	if err := w.Flush(); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to write asset list", err)
+	}
+	return nil
+}
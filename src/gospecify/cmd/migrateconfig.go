@@ -0,0 +1,81 @@
+// Package cmd provides the CLI commands for gospecify
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewMigrateConfigCmd creates the migrate-config command
+func NewMigrateConfigCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate-config",
+		Short: "Upgrade a config file to the current schema version",
+		Long: fmt.Sprintf(`Upgrade a gospecify config file to the current schema version.
+
+The file named by --config (default %s) is read, migrated one schema
+version at a time, and rewritten in place. Comments are kept where the
+YAML structure allows.
+
+Examples:
+  gospecify migrate-config
+  gospecify migrate-config --dry-run
+  gospecify migrate-config --config team.yaml`, config.DefaultConfigFile),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, _ := cmd.Flags().GetString("config")
+			if path == "" {
+				path = config.DefaultConfigFile
+			}
+			return runMigrateConfig(path, dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false,
+		"Print the migrated file instead of rewriting it")
+
+	return cmd
+}
+
+// runMigrateConfig executes the migrate-config command
+func runMigrateConfig(path string, dryRun bool) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.NewInvalidConfig(fmt.Sprintf("config file %s does not exist", path))
+		}
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to access config file", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to read config file", err)
+	}
+
+	migrated, from, err := config.MigrateConfigFile(data, path)
+	if err != nil {
+		return err
+	}
+	if from == config.ConfigFileVersion {
+		fmt.Printf("✅ %s is already at schema version %d\n", path, from)
+		return nil
+	}
+
+	if dryRun {
+		fmt.Printf("📋 %s would be migrated from schema version %d to %d:\n\n", path, from, config.ConfigFileVersion)
+		fmt.Print(string(migrated))
+		return nil
+	}
+
+	if err := os.WriteFile(path, migrated, info.Mode().Perm()); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to write config file", err)
+	}
+	fmt.Printf("✅ Migrated %s from schema version %d to %d\n", path, from, config.ConfigFileVersion)
+	return nil
+}
@@ -0,0 +1,167 @@
+// Package cmd provides the CLI commands for gospecify
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/manifest"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/scaffold"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/templates"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/ui"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewRecoverCmd creates the recover command
+func NewRecoverCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recover",
+		Short: "Restore missing or damaged managed files from the manifest",
+		Long: `Restore the Specify project in the current directory to its managed
+baseline.
+
+Every file recorded in .specify/manifest.json that is missing or whose
+SHA-256 no longer matches is regenerated, using the assistant, script type
+and template set recorded there. Only files that can be reproduced exactly
+are written; the others are reported. Unlike update, nothing is upgraded.
+
+Examples:
+  gospecify recover`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			theme, err := loadTheme(cmd)
+			if err != nil {
+				return err
+			}
+			// Unrestorable files are reported above the error; usage would bury them
+			cmd.SilenceUsage = true
+			return runRecover(theme)
+		},
+	}
+
+	return cmd
+}
+
+// runRecover executes the recover command
+func runRecover(theme *ui.Theme) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to get current directory", err)
+	}
+
+	if !manifest.Exists(projectPath) {
+		return errors.NewValidationError(fmt.Sprintf(
+			"no %s found in %s; recover needs the manifest (use 'gospecify update' or 'gospecify init --here --force' instead)",
+			manifest.RelativePath, projectPath))
+	}
+	recorded, err := manifest.Load(projectPath)
+	if err != nil {
+		return err
+	}
+
+	cfg := &config.ProjectConfig{
+		Path:        projectPath,
+		AIAssistant: recorded.AIAssistant,
+		ScriptType:  recorded.ScriptType,
+		TemplateSet: recorded.TemplateSet,
+	}
+	if cfg.TemplateSet == "" {
+		cfg.TemplateSet = config.DefaultTemplateSet
+	}
+	if cfg.ScriptType == "" {
+		cfg.ScriptType = scaffold.DetectScriptType(projectPath)
+	}
+
+	// Find the damaged files before touching the templates
+	var damaged []string
+	for _, entry := range recorded.Files {
+		content, err := os.ReadFile(filepath.Join(projectPath, filepath.FromSlash(entry.Path)))
+		if err == nil && manifest.Hash(content) == entry.SHA256 {
+			continue
+		}
+		if err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to read "+entry.Path, err)
+		}
+		damaged = append(damaged, entry.Path)
+	}
+
+	fmt.Println(theme.InfoPanel.Render(fmt.Sprintf("🩹 Recovering Specify project in %s", projectPath)))
+	fmt.Println()
+
+	if len(damaged) == 0 {
+		fmt.Println(theme.SuccessPanel.Render(fmt.Sprintf("🎉 All %d managed files match the manifest", len(recorded.Files))))
+		return nil
+	}
+
+	assistants := config.DetectAssistants(projectPath)
+	if _, exists := config.AIAssistants[cfg.AIAssistant]; exists && !slices.Contains(assistants, cfg.AIAssistant) {
+		assistants = append(assistants, cfg.AIAssistant)
+	}
+	if len(assistants) == 0 {
+		return errors.NewValidationError("could not determine the project's AI assistant from the manifest or its command directories")
+	}
+
+	assets, err := scaffold.LoadAssets(templates.EmbeddedSource{SetName: cfg.TemplateSet})
+	if err != nil {
+		return err
+	}
+
+	expected := make(map[string][]byte)
+	for _, key := range assistants {
+		assistant := config.AIAssistants[key]
+		files, err := scaffold.ExpectedProjectFiles(assets, &assistant, cfg.ScriptType, recorded.Replacements, scaffold.SharedAgents(assistants), recorded.Commands, recorded.KeepTemplateStructure)
+		if err != nil {
+			return err
+		}
+		for relPath, content := range files {
+			expected[relPath] = content
+		}
+	}
+
+	tracker := &config.StepTracker{Title: "Restoring managed files"}
+	for _, relPath := range damaged {
+		tracker.Add(relPath, relPath)
+	}
+
+	writer := scaffold.NewProjectWriter(projectPath, recorded)
+	var unrecoverable []string
+	for _, relPath := range damaged {
+		entry, _ := recorded.Lookup(relPath)
+		content, known := expected[relPath]
+
+		// Anything else would not be the baseline the manifest describes
+		if !known || manifest.Hash(content) != entry.SHA256 {
+			tracker.Error(relPath, "cannot be reproduced exactly by this gospecify")
+			unrecoverable = append(unrecoverable, relPath)
+			continue
+		}
+
+		perm := os.FileMode(0644)
+		if strings.HasPrefix(relPath, ".specify/scripts/") {
+			perm = 0755
+		}
+		if err := writer.WriteFile(relPath, content, perm); err != nil {
+			tracker.Error(relPath, err.Error())
+			return err
+		}
+		tracker.Complete(relPath, "restored")
+	}
+
+	fmt.Println(ui.NewLiveProgress(tracker).Render())
+
+	restored := len(damaged) - len(unrecoverable)
+	if len(unrecoverable) > 0 {
+		fmt.Println(theme.WarningPanel.Render(fmt.Sprintf(
+			"⚠️  %d restored, %d could not be reproduced exactly.\nThey were generated with other values or templates; 'gospecify init --here --force' regenerates them, scripts included, from the current templates.",
+			restored, len(unrecoverable))))
+		return errors.NewTemplateError(fmt.Sprintf("%d managed files could not be restored", len(unrecoverable)), nil)
+	}
+
+	fmt.Println(theme.SuccessPanel.Render(fmt.Sprintf("✅ %d managed files restored", restored)))
+	return nil
+}
@@ -35,9 +35,27 @@ GitHub Spec Kit - Spec-Driven Development Toolkit`, config.Banner, config.Taglin
 		},
 	}
 
+	// Global flags
+	cmd.PersistentFlags().String("config", "",
+		"Load settings from this YAML config file instead of ./"+config.DefaultConfigFile+" or ~/"+config.DefaultConfigFile+"; flags passed on the command line take precedence")
+	cmd.PersistentFlags().String("output", config.OutputText,
+		"Output format: text, or json for a single machine-readable document at the end (init only)")
+
+	// The completion command below replaces cobra's default one
+	cmd.CompletionOptions.DisableDefaultCmd = true
+
 	// Add subcommands
 	cmd.AddCommand(NewInitCmd())
+	cmd.AddCommand(NewAuditCmd())
 	cmd.AddCommand(NewCheckCmd())
+	cmd.AddCommand(NewCleanCmd())
+	cmd.AddCommand(NewCompletionCmd())
+	cmd.AddCommand(NewDoctorCmd())
+	cmd.AddCommand(NewExportCmd())
+	cmd.AddCommand(NewListCmd())
+	cmd.AddCommand(NewMigrateConfigCmd())
+	cmd.AddCommand(NewUpdateCmd())
+	cmd.AddCommand(NewRecoverCmd())
 	cmd.AddCommand(NewVersionCmd())
 
 	return cmd
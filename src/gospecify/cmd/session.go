@@ -0,0 +1,220 @@
+// Package cmd provides the CLI commands for gospecify
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// sessionVersion is the current init session file schema version
+const sessionVersion = 1
+
+// initSession captures every resolved init choice, including interactive
+// selections, so the run can be repeated with --replay. Credentials are
+// never recorded.
+type initSession struct {
+	Version               int               `json:"version"`
+	GeneratedBy           string            `json:"generated_by"`
+	Projects              []string          `json:"projects,omitempty"`
+	Here                  bool              `json:"here,omitempty"`
+	Name                  string            `json:"name,omitempty"`
+	AIAssistant           string            `json:"ai_assistant"`
+	ScriptType            string            `json:"script_type"`
+	TemplateSet           string            `json:"template_set"`
+	NoGit                 bool              `json:"no_git"`
+	Force                 bool              `json:"force"`
+	IgnoreTools           bool              `json:"ignore_agent_tools"`
+	SkipTLS               bool              `json:"skip_tls"`
+	Debug                 bool              `json:"debug"`
+	LogLevel              string            `json:"log_level,omitempty"`
+	CompactProgress       bool              `json:"compact_progress"`
+	CleanBefore           bool              `json:"clean_before"`
+	Describe              string            `json:"describe,omitempty"`
+	CopyScriptsToAgent    bool              `json:"copy_scripts_to_agent"`
+	KeepTemplateStructure bool              `json:"keep_template_structure"`
+	Owner                 string            `json:"owner,omitempty"`
+	Values                map[string]string `json:"values,omitempty"`
+	From                  string            `json:"from,omitempty"`
+	Ref                   string            `json:"ref,omitempty"`
+	TemplateRepo          string            `json:"template_repo,omitempty"`
+	TemplateRef           string            `json:"template_ref,omitempty"`
+	TemplateDir           string            `json:"template_dir,omitempty"`
+	UseRelease            bool              `json:"use_release,omitempty"`
+	AllowSymlinks         bool              `json:"allow_symlinks,omitempty"`
+	GitHubAPIURL          string            `json:"github_api_url,omitempty"`
+	Mirror                string            `json:"mirror,omitempty"`
+	RollbackOnError       bool              `json:"rollback_on_error,omitempty"`
+	Gitignore             bool              `json:"gitignore,omitempty"`
+	Commands              []string          `json:"commands,omitempty"`
+	Timestamp             string            `json:"timestamp,omitempty"`
+	WriteConcurrency      int               `json:"write_concurrency"`
+	WithEditorConfig      bool              `json:"with_editor_config"`
+	Branch                string            `json:"branch,omitempty"`
+	GitCommitMessage      string            `json:"git_commit_message,omitempty"`
+	GitAuthor             string            `json:"git_author,omitempty"`
+	NoGitChmod            bool              `json:"no_git_chmod"`
+	CommandsOnly          bool              `json:"commands_only"`
+	Verify                bool              `json:"verify"`
+	FixLineEndings        bool              `json:"fix_line_endings"`
+	Timeout               time.Duration     `json:"timeout,omitempty"`
+	TempDir               string            `json:"temp_dir,omitempty"`
+}
+
+// newInitSession snapshots the resolved configuration of a completed run
+func newInitSession(cfg *config.ProjectConfig, projects []string, timestamp string) *initSession {
+	return &initSession{
+		Version:               sessionVersion,
+		GeneratedBy:           config.UserAgent,
+		Projects:              projects,
+		Here:                  cfg.Here,
+		Name:                  hereName(cfg),
+		AIAssistant:           cfg.AIAssistant,
+		ScriptType:            cfg.ScriptType,
+		TemplateSet:           cfg.TemplateSet,
+		NoGit:                 cfg.NoGit,
+		Force:                 cfg.Force,
+		IgnoreTools:           cfg.IgnoreTools,
+		SkipTLS:               cfg.SkipTLS,
+		Debug:                 cfg.Debug,
+		LogLevel:              cfg.LogLevel,
+		CompactProgress:       cfg.CompactProgress,
+		CleanBefore:           cfg.CleanBefore,
+		Describe:              cfg.Describe,
+		CopyScriptsToAgent:    cfg.CopyScriptsToAgent,
+		KeepTemplateStructure: cfg.KeepTemplateStructure,
+		Owner:                 cfg.Owner,
+		Values:                cfg.Values,
+		From:                  cfg.From,
+		Ref:                   cfg.Ref,
+		TemplateRepo:          cfg.TemplateRepo,
+		TemplateRef:           cfg.TemplateRef,
+		TemplateDir:           cfg.TemplateDir,
+		UseRelease:            cfg.UseRelease,
+		AllowSymlinks:         cfg.AllowSymlinks,
+		GitHubAPIURL:          cfg.GitHubAPIURL,
+		Mirror:                cfg.Mirror,
+		RollbackOnError:       cfg.RollbackOnError,
+		Gitignore:             cfg.Gitignore,
+		Commands:              cfg.Commands,
+		Timestamp:             timestamp,
+		WriteConcurrency:      cfg.WriteConcurrency,
+		WithEditorConfig:      cfg.WithEditorConfig,
+		Branch:                cfg.Branch,
+		GitCommitMessage:      cfg.GitCommitMessage,
+		GitAuthor:             cfg.GitAuthor,
+		NoGitChmod:            cfg.NoGitChmod,
+		CommandsOnly:          cfg.CommandsOnly,
+		Verify:                cfg.Verify,
+		FixLineEndings:        cfg.FixLineEndings,
+		Timeout:               cfg.Timeout,
+		TempDir:               cfg.TempDir,
+	}
+}
+
+// hereName returns the project name resolved for --here, which names
+// the project without naming a directory
+func hereName(cfg *config.ProjectConfig) string {
+	if cfg.Here {
+		return cfg.Name
+	}
+	return ""
+}
+
+// apply overwrites cfg with the recorded choices
+func (s *initSession) apply(cfg *config.ProjectConfig) {
+	cfg.Here = s.Here
+	if s.Here {
+		cfg.Name = s.Name
+	}
+	cfg.AIAssistant = s.AIAssistant
+	cfg.ScriptType = s.ScriptType
+	cfg.TemplateSet = s.TemplateSet
+	cfg.NoGit = s.NoGit
+	cfg.Force = s.Force
+	cfg.IgnoreTools = s.IgnoreTools
+	cfg.SkipTLS = s.SkipTLS
+	cfg.Debug = s.Debug
+	if s.LogLevel != "" {
+		cfg.LogLevel = s.LogLevel
+	}
+	cfg.CompactProgress = s.CompactProgress
+	cfg.CleanBefore = s.CleanBefore
+	cfg.Describe = s.Describe
+	cfg.CopyScriptsToAgent = s.CopyScriptsToAgent
+	cfg.KeepTemplateStructure = s.KeepTemplateStructure
+	cfg.Owner = s.Owner
+	cfg.Values = s.Values
+	cfg.From = s.From
+	cfg.Ref = s.Ref
+	cfg.TemplateRepo = s.TemplateRepo
+	cfg.TemplateRef = s.TemplateRef
+	cfg.TemplateDir = s.TemplateDir
+	cfg.UseRelease = s.UseRelease
+	cfg.AllowSymlinks = s.AllowSymlinks
+	cfg.GitHubAPIURL = s.GitHubAPIURL
+	cfg.Mirror = s.Mirror
+	cfg.RollbackOnError = s.RollbackOnError
+	cfg.Gitignore = s.Gitignore
+	cfg.Commands = s.Commands
+	cfg.WriteConcurrency = s.WriteConcurrency
+	cfg.WithEditorConfig = s.WithEditorConfig
+	if s.Branch != "" {
+		cfg.Branch = s.Branch
+	}
+	cfg.GitCommitMessage = s.GitCommitMessage
+	cfg.GitAuthor = s.GitAuthor
+	cfg.NoGitChmod = s.NoGitChmod
+	cfg.CommandsOnly = s.CommandsOnly
+	cfg.Verify = s.Verify
+	cfg.FixLineEndings = s.FixLineEndings
+	// An explicit --timeout on replay wins over the recorded one
+	if cfg.Timeout == 0 {
+		cfg.Timeout = s.Timeout
+	}
+	cfg.TempDir = s.TempDir
+}
+
+// saveSession writes the session file to path
+func saveSession(path string, session *initSession) error {
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to encode session", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to write session file", err)
+	}
+	return nil
+}
+
+// loadSession reads and validates a session file written by --record
+func loadSession(path string) (*initSession, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeFileSystemError, "failed to read session file", err)
+	}
+
+	var session initSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInvalidConfig, "failed to parse session file", err)
+	}
+	if session.Version < 1 {
+		return nil, errors.NewInvalidConfig(fmt.Sprintf("%s is not a gospecify session file (missing version)", path))
+	}
+	if session.Version > sessionVersion {
+		return nil, errors.NewInvalidConfig(
+			fmt.Sprintf("session version %d is newer than supported version %d; upgrade gospecify", session.Version, sessionVersion))
+	}
+	if session.Here == (len(session.Projects) > 0) {
+		return nil, errors.NewInvalidConfig(fmt.Sprintf("%s must record either project names or here", path))
+	}
+	if session.AIAssistant == "" || session.ScriptType == "" {
+		return nil, errors.NewInvalidConfig(fmt.Sprintf("%s is missing the AI assistant or script type", path))
+	}
+
+	return &session, nil
+}
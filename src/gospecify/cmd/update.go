@@ -0,0 +1,210 @@
+// Package cmd provides the CLI commands for gospecify
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/manifest"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/scaffold"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/templates"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/ui"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewUpdateCmd creates the update command
+func NewUpdateCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Refresh an existing project's templates and commands",
+		Long: `Refresh the templates and assistant commands of the Specify project in
+the current directory from the templates built into this gospecify.
+
+Files that still match what gospecify generated last time (according to
+.specify/manifest.json) are updated, and new templates are added. Files
+you have edited since are kept unless --force is given. Scripts are not
+touched.
+
+Examples:
+  gospecify update
+  gospecify update --force`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			theme, err := loadTheme(cmd)
+			if err != nil {
+				return err
+			}
+			return runUpdate(theme, force)
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false,
+		"Overwrite every template and command, including ones with local edits")
+
+	return cmd
+}
+
+// runUpdate executes the update command
+func runUpdate(theme *ui.Theme, force bool) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to get current directory", err)
+	}
+
+	if info, err := os.Stat(filepath.Join(projectPath, ".specify")); err != nil || !info.IsDir() {
+		return errors.NewValidationError(
+			fmt.Sprintf("no .specify directory found in %s (run 'gospecify init --here' first)", projectPath))
+	}
+
+	// The previous manifest tells local edits apart from stale generated files
+	var previous *manifest.Manifest
+	if manifest.Exists(projectPath) {
+		if previous, err = manifest.Load(projectPath); err != nil {
+			return err
+		}
+	}
+
+	cfg := &config.ProjectConfig{Path: projectPath, TemplateSet: config.DefaultTemplateSet}
+	var replacements map[string]string
+	keepStructure := false
+	if previous != nil {
+		replacements = previous.Replacements
+		keepStructure = previous.KeepTemplateStructure
+		cfg.AIAssistant = previous.AIAssistant
+		cfg.ScriptType = previous.ScriptType
+		cfg.Commands = previous.Commands
+		if previous.TemplateSet != "" {
+			cfg.TemplateSet = previous.TemplateSet
+		}
+	}
+	if cfg.ScriptType == "" {
+		cfg.ScriptType = scaffold.DetectScriptType(projectPath)
+	}
+	if cfg.ScriptType == "" {
+		cfg.ScriptType = config.ScriptTypeBash
+	}
+
+	assistants := config.DetectAssistants(projectPath)
+	if cfg.AIAssistant != "" && !slices.Contains(assistants, cfg.AIAssistant) {
+		assistants = append(assistants, cfg.AIAssistant)
+	}
+	if len(assistants) == 0 {
+		return errors.NewValidationError("could not detect an AI assistant command directory in this project")
+	}
+
+	if cfg.CreatedAt, err = config.ResolveTimestamp(""); err != nil {
+		return err
+	}
+
+	assets, err := scaffold.LoadAssets(templates.EmbeddedSource{SetName: cfg.TemplateSet})
+	if err != nil {
+		return err
+	}
+
+	// Scripts may be customized or laid out differently, so only templates and commands are refreshed
+	expected := make(map[string][]byte)
+	for _, key := range assistants {
+		assistant, exists := config.AIAssistants[key]
+		if !exists {
+			continue
+		}
+		files, err := scaffold.ExpectedProjectFiles(assets, &assistant, cfg.ScriptType, replacements, scaffold.SharedAgents(assistants), cfg.Commands, keepStructure)
+		if err != nil {
+			return err
+		}
+		for relPath, content := range files {
+			if !strings.HasPrefix(relPath, ".specify/scripts/") {
+				expected[relPath] = content
+			}
+		}
+	}
+
+	paths := make([]string, 0, len(expected))
+	for relPath := range expected {
+		paths = append(paths, relPath)
+	}
+	sort.Strings(paths)
+
+	fmt.Println(theme.InfoPanel.Render(fmt.Sprintf("🔄 Updating Specify project in %s", projectPath)))
+	fmt.Println()
+
+	tracker := &config.StepTracker{Title: "Updating templates and commands"}
+	for _, relPath := range paths {
+		tracker.Add(relPath, relPath)
+	}
+
+	updatedManifest := previous
+	if updatedManifest == nil {
+		updatedManifest = manifest.New(cfg)
+	}
+	writer := scaffold.NewProjectWriter(projectPath, updatedManifest)
+
+	var updated, added, kept int
+	for _, relPath := range paths {
+		content := expected[relPath]
+		onDisk, readErr := os.ReadFile(filepath.Join(projectPath, filepath.FromSlash(relPath)))
+
+		var detail string
+		switch {
+		case readErr != nil && os.IsNotExist(readErr):
+			detail = "added"
+			added++
+		case readErr != nil:
+			tracker.Error(relPath, readErr.Error())
+			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to read "+relPath, readErr)
+		case bytes.Equal(onDisk, content):
+			updatedManifest.Add(relPath, content)
+			tracker.Skip(relPath, "already up to date")
+			continue
+		case isGenerated(previous, relPath, onDisk):
+			detail = "updated"
+			updated++
+		case force:
+			detail = "updated, local edits overwritten"
+			updated++
+		default:
+			tracker.Skip(relPath, "kept local edits")
+			kept++
+			continue
+		}
+
+		if err := writer.WriteFile(relPath, content, 0644); err != nil {
+			tracker.Error(relPath, err.Error())
+			return err
+		}
+		tracker.Complete(relPath, detail)
+	}
+
+	if err := updatedManifest.Save(projectPath); err != nil {
+		return err
+	}
+
+	fmt.Println(ui.NewLiveProgress(tracker).Render())
+
+	summary := fmt.Sprintf("✅ %d updated, %d added, %d kept with local edits", updated, added, kept)
+	if kept > 0 {
+		summary += "\nRun 'gospecify update --force' to overwrite the edited files."
+		fmt.Println(theme.WarningPanel.Render(summary))
+		return nil
+	}
+	fmt.Println(theme.SuccessPanel.Render(summary))
+	return nil
+}
+
+// isGenerated reports whether content is exactly what gospecify last wrote to relPath
+func isGenerated(previous *manifest.Manifest, relPath string, content []byte) bool {
+	if previous == nil {
+		return false
+	}
+	entry, managed := previous.Lookup(relPath)
+	return managed && entry.SHA256 == manifest.Hash(content)
+}
@@ -2,22 +2,59 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 
 	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
+// versionInfo is the JSON document printed by version --json
+type versionInfo struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
+}
+
 // NewVersionCmd creates the version command
 func NewVersionCmd() *cobra.Command {
-	return &cobra.Command{
+	var short, asJSON bool
+
+	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Show version information",
-		Long:  "Show version information for gospecify",
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Printf("gospecify %s\n", config.Version)
-			fmt.Printf("Commit: %s\n", config.Commit)
-			fmt.Printf("Built: %s\n", config.Date)
+		Long: `Show version information for gospecify.
+
+Use --short or --json for output that scripts can parse reliably.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch {
+			case short:
+				fmt.Println(config.Version)
+			case asJSON:
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(versionInfo{
+					Version: config.Version,
+					Commit:  config.Commit,
+					Date:    config.Date,
+				}); err != nil {
+					return errors.Wrap(errors.ErrCodeFileSystemError, "failed to write version information", err)
+				}
+			default:
+				fmt.Printf("gospecify %s\n", config.Version)
+				fmt.Printf("Commit: %s\n", config.Commit)
+				fmt.Printf("Built: %s\n", config.Date)
+			}
+			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&short, "short", false, "Print only the version number")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print version, commit, and build date as JSON")
+	cmd.MarkFlagsMutuallyExclusive("short", "json")
+
+	return cmd
 }
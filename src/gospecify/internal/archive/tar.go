@@ -0,0 +1,78 @@
+// Package archive provides writers for packaging generated project files
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// Entry is a single file to be written into an archive
+type Entry struct {
+	Path    string
+	Content []byte
+	Mode    os.FileMode
+}
+
+// WriteTarGz writes the entries as a gzip-compressed tarball, adding parent
+// directory entries and sorting paths so the output is stable
+func WriteTarGz(w io.Writer, entries []Entry, modTime time.Time) error {
+	sorted := append([]Entry(nil), entries...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	writtenDirs := make(map[string]bool)
+	var writeDir func(dir string) error
+	writeDir = func(dir string) error {
+		if dir == "." || dir == "/" || writtenDirs[dir] {
+			return nil
+		}
+		if err := writeDir(path.Dir(dir)); err != nil {
+			return err
+		}
+		writtenDirs[dir] = true
+		return tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeDir,
+			Name:     dir + "/",
+			Mode:     0755,
+			ModTime:  modTime,
+		})
+	}
+
+	for _, entry := range sorted {
+		if err := writeDir(path.Dir(entry.Path)); err != nil {
+			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to write archive directory", err)
+		}
+
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     entry.Path,
+			Mode:     int64(entry.Mode.Perm()),
+			Size:     int64(len(entry.Content)),
+			ModTime:  modTime,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to write archive header", err)
+		}
+		if _, err := tw.Write(entry.Content); err != nil {
+			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to write archive entry", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to finalize archive", err)
+	}
+	if err := gz.Close(); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to finalize compression", err)
+	}
+
+	return nil
+}
@@ -1,6 +1,12 @@
 // Package config provides configuration structures and constants for gospecify
 package config
 
+import (
+	"os"
+	"path/filepath"
+	"sort"
+)
+
 // AIAssistant represents an AI coding assistant configuration
 type AIAssistant struct {
 	Key        string     `json:"key"`
@@ -11,6 +17,20 @@ type AIAssistant struct {
 	ArgFormat  string     `json:"arg_format"`
 	IsIDEBased bool       `json:"is_ide_based"`
 	Website    string     `json:"website"`
+	// DefaultScriptType pre-selects a script type when --script is not given
+	DefaultScriptType string `json:"default_script_type,omitempty"`
+	// CommandFile, when set, aggregates every command into this single file
+	// inside Directory instead of writing one file per command
+	CommandFile string `json:"command_file,omitempty"`
+}
+
+// PreferredScriptType returns the assistant's default script type, falling
+// back to the default for the current operating system
+func (a AIAssistant) PreferredScriptType() string {
+	if _, exists := ScriptTypes[a.DefaultScriptType]; exists {
+		return a.DefaultScriptType
+	}
+	return DefaultScriptType()
 }
 
 // FileFormat represents the file format used by an AI assistant
@@ -22,16 +42,36 @@ const (
 	FormatPrompt   FileFormat = "prompt.md"
 )
 
+// AIAssistantAll is the --ai value that scaffolds every supported assistant
+const AIAssistantAll = "all"
+
+// DefaultAIAssistant is pre-selected interactively and leads --ai all
+const DefaultAIAssistant = "claude"
+
+// AllAssistantKeys returns every assistant key, DefaultAIAssistant first and
+// the rest sorted
+func AllAssistantKeys() []string {
+	keys := make([]string, 0, len(AIAssistants))
+	for key := range AIAssistants {
+		if key != DefaultAIAssistant {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return append([]string{DefaultAIAssistant}, keys...)
+}
+
 // AIAssistants contains all supported AI assistants with their configurations
 var AIAssistants = map[string]AIAssistant{
 	"copilot": {
-		Key:        "copilot",
-		Name:       "GitHub Copilot",
-		Directory:  ".github/prompts/",
-		Format:     FormatPrompt,
-		ArgFormat:  "$ARGUMENTS",
-		IsIDEBased: true,
-		Website:    "https://github.com/features/copilot",
+		Key:               "copilot",
+		Name:              "GitHub Copilot",
+		Directory:         ".github/prompts/",
+		Format:            FormatPrompt,
+		ArgFormat:         "$ARGUMENTS",
+		IsIDEBased:        true,
+		Website:           "https://github.com/features/copilot",
+		DefaultScriptType: ScriptTypePowerShell,
 	},
 	"claude": {
 		Key:       "claude",
@@ -79,22 +119,24 @@ var AIAssistants = map[string]AIAssistant{
 		Website:   "https://opencode.ai",
 	},
 	"codex": {
-		Key:       "codex",
-		Name:      "Codex CLI",
-		Directory: ".codex/",
-		Format:    FormatMarkdown,
-		CLITool:   "codex",
-		ArgFormat: "$ARGUMENTS",
-		Website:   "https://github.com/microsoft/codex-cli",
+		Key:         "codex",
+		Name:        "Codex CLI",
+		Directory:   ".codex/",
+		Format:      FormatMarkdown,
+		CLITool:     "codex",
+		ArgFormat:   "$ARGUMENTS",
+		Website:     "https://github.com/microsoft/codex-cli",
+		CommandFile: "commands.md",
 	},
 	"windsurf": {
-		Key:        "windsurf",
-		Name:       "Windsurf",
-		Directory:  ".windsurf/workflows/",
-		Format:     FormatMarkdown,
-		ArgFormat:  "$ARGUMENTS",
-		IsIDEBased: true,
-		Website:    "https://codeium.com/windsurf",
+		Key:               "windsurf",
+		Name:              "Windsurf",
+		Directory:         ".windsurf/workflows/",
+		Format:            FormatMarkdown,
+		ArgFormat:         "$ARGUMENTS",
+		IsIDEBased:        true,
+		Website:           "https://codeium.com/windsurf",
+		DefaultScriptType: ScriptTypePowerShell,
 	},
 	"kilocode": {
 		Key:       "kilocode",
@@ -154,3 +196,17 @@ var AgentFolderMap = map[string]string{
 	"copilot":  ".github/",
 	"roo":      ".roo/",
 }
+
+// DetectAssistants returns the sorted keys of assistants whose command
+// directory already exists in projectPath
+func DetectAssistants(projectPath string) []string {
+	var detected []string
+	for key, assistant := range AIAssistants {
+		info, err := os.Stat(filepath.Join(projectPath, assistant.Directory))
+		if err == nil && info.IsDir() {
+			detected = append(detected, key)
+		}
+	}
+	sort.Strings(detected)
+	return detected
+}
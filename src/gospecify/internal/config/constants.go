@@ -1,6 +1,13 @@
 // Package config provides configuration structures and constants for gospecify
 package config
 
+import (
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+)
+
 // Version information
 const (
 	Version   = "0.1.0"
@@ -14,6 +21,15 @@ const (
 	GitHubOwner = "github"
 	GitHubRepo  = "spec-kit"
 	GitHubAPI   = "https://api.github.com"
+
+	// GitHubDownloadHost serves the browser_download_url of github.com release assets
+	GitHubDownloadHost = "github.com"
+
+	// GitHubAPIURLEnv names the environment variable that overrides GitHubAPI
+	GitHubAPIURLEnv = "GITHUB_API_URL"
+
+	// GitHubPrivateRepoScope is the classic token scope needed to read private repositories
+	GitHubPrivateRepoScope = "repo"
 )
 
 // Default paths and directories
@@ -21,12 +37,74 @@ const (
 	DefaultTemplateDir = "templates"
 	DefaultScriptDir   = "scripts"
 	DefaultConfigFile  = ".gospecify.yaml"
+	DefaultTemplateSet = "default"
+
+	// DefaultWriteConcurrency is the number of files written in parallel during init
+	DefaultWriteConcurrency = 4
+
+	// DefaultReleaseCacheTTL is how long a cached release lookup is used without asking GitHub
+	DefaultReleaseCacheTTL = time.Hour
+
+	// DefaultBranch is the initial branch of repositories created by init
+	DefaultBranch = "main"
+
+	// DefaultCommitMessage is the message of the initial commit made by init
+	DefaultCommitMessage = "Initial commit - Specify project setup"
+
+	// DefaultAddCommitMessage is the message of the commit --commit makes in an existing repository
+	DefaultAddCommitMessage = "Add Specify project files"
 )
 
+// Output formats accepted by the global --output flag
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// Log levels accepted by init's --log-level flag
+const (
+	LogLevelDebug = "debug"
+	LogLevelInfo  = "info"
+	LogLevelWarn  = "warn"
+	LogLevelError = "error"
+
+	// DefaultLogLevel only lets warnings and errors through
+	DefaultLogLevel = LogLevelWarn
+)
+
+// LogLevels lists the accepted --log-level values from most to least verbose
+var LogLevels = []string{LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError}
+
+// AccessibleEnv enables accessible output when set to a true value
+const AccessibleEnv = "GOSPECIFY_ACCESSIBLE"
+
+// AccessibleFromEnv reports whether AccessibleEnv requests accessible output
+func AccessibleFromEnv() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(AccessibleEnv))
+	return err == nil && enabled
+}
+
 // Script types
 const (
 	ScriptTypeBash       = "sh"
 	ScriptTypePowerShell = "ps"
+	ScriptTypeFish       = "fish"
+	ScriptTypeNushell    = "nu"
+)
+
+// DefaultScriptType returns the script type native to the current operating system
+func DefaultScriptType() string {
+	if runtime.GOOS == "windows" {
+		return ScriptTypePowerShell
+	}
+	return ScriptTypeBash
+}
+
+// Panel border styles accepted by the ui.border config option
+const (
+	BorderRounded = "rounded"
+	BorderThick   = "thick"
+	BorderNone    = "none"
 )
 
 // Script type configurations
@@ -43,6 +121,18 @@ var ScriptTypes = map[string]ScriptType{
 		Extension: ".ps1",
 		Platform:  "windows",
 	},
+	"fish": {
+		Key:       "fish",
+		Name:      "Fish",
+		Extension: ".fish",
+		Platform:  "unix",
+	},
+	"nu": {
+		Key:       "nu",
+		Name:      "Nushell",
+		Extension: ".nu",
+		Platform:  "cross-platform",
+	},
 }
 
 // ScriptType represents a script execution environment
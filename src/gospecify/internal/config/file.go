@@ -0,0 +1,121 @@
+// Package config provides configuration structures and constants for gospecify
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// FileConfig is the schema of a gospecify configuration file. Unset fields
+// leave the corresponding flag defaults untouched.
+type FileConfig struct {
+	// Version is the schema version; files without one predate versioning
+	Version int `yaml:"version,omitempty"`
+
+	AI               string `yaml:"ai,omitempty"`
+	Script           string `yaml:"script,omitempty"`
+	TemplateSet      string `yaml:"template_set,omitempty"`
+	Owner            string `yaml:"owner,omitempty"`
+	Proxy            string `yaml:"proxy,omitempty"`
+	GitHubAPIURL     string `yaml:"github_api_url,omitempty"`
+	Mirror           string `yaml:"mirror,omitempty"`
+	LogLevel         string `yaml:"log_level,omitempty"`
+	NoGit            *bool  `yaml:"no_git,omitempty"`
+	IgnoreAgentTools *bool  `yaml:"ignore_agent_tools,omitempty"`
+	SkipTLS          *bool  `yaml:"skip_tls,omitempty"`
+	Debug            *bool  `yaml:"debug,omitempty"`
+	CompactProgress  *bool  `yaml:"compact_progress,omitempty"`
+	// NoGitkeep no longer has any effect; it is accepted so older files still load
+	NoGitkeep *bool `yaml:"no_gitkeep,omitempty"`
+
+	UI UIConfig `yaml:"ui,omitempty"`
+}
+
+// FindConfigFile returns the path of the DefaultConfigFile in the current
+// directory, or failing that in the home directory, or "" if neither exists
+func FindConfigFile() string {
+	dirs := []string{"."}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, home)
+	}
+	for _, dir := range dirs {
+		path := filepath.Join(dir, DefaultConfigFile)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// LoadConfigFile reads and validates the configuration file at path
+func LoadConfigFile(path string) (*FileConfig, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.NewInvalidConfig(fmt.Sprintf("config file %s does not exist", path))
+		}
+		return nil, errors.Wrap(errors.ErrCodeFileSystemError, "failed to access config file", err)
+	}
+	if info.IsDir() {
+		return nil, errors.NewInvalidConfig(fmt.Sprintf("config file %s is a directory", path))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeFileSystemError, "failed to read config file", err)
+	}
+
+	return ParseConfigFile(data, path)
+}
+
+// ParseConfigFile decodes and validates configuration file contents read from path
+func ParseConfigFile(data []byte, path string) (*FileConfig, error) {
+	var fileCfg FileConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&fileCfg); err != nil && err != io.EOF {
+		return nil, errors.Wrap(errors.ErrCodeInvalidConfig, fmt.Sprintf("failed to parse config file %s", path), err)
+	}
+
+	if fileCfg.Version < 0 {
+		return nil, errors.NewInvalidConfig(fmt.Sprintf("config file %s: version %d must not be negative", path, fileCfg.Version))
+	}
+	if fileCfg.Version > ConfigFileVersion {
+		return nil, errors.NewInvalidConfig(fmt.Sprintf(
+			"config file %s has schema version %d, newer than supported version %d; upgrade gospecify",
+			path, fileCfg.Version, ConfigFileVersion))
+	}
+
+	if fileCfg.AI != "" {
+		if _, exists := AIAssistants[fileCfg.AI]; !exists {
+			return nil, errors.NewInvalidConfig(fmt.Sprintf("config file %s: unknown ai %q", path, fileCfg.AI))
+		}
+	}
+	if fileCfg.Script != "" {
+		if _, exists := ScriptTypes[fileCfg.Script]; !exists {
+			return nil, errors.NewInvalidConfig(fmt.Sprintf("config file %s: unknown script %q", path, fileCfg.Script))
+		}
+	}
+
+	if fileCfg.LogLevel != "" && !slices.Contains(LogLevels, fileCfg.LogLevel) {
+		return nil, errors.NewInvalidConfig(fmt.Sprintf("config file %s: unknown log_level %q (expected %s)",
+			path, fileCfg.LogLevel, strings.Join(LogLevels, ", ")))
+	}
+
+	switch fileCfg.UI.Border {
+	case "", BorderRounded, BorderThick, BorderNone:
+	default:
+		return nil, errors.NewInvalidConfig(fmt.Sprintf("config file %s: unknown ui.border %q (expected %s, %s, or %s)",
+			path, fileCfg.UI.Border, BorderRounded, BorderThick, BorderNone))
+	}
+
+	return &fileCfg, nil
+}
@@ -0,0 +1,108 @@
+// Package config provides configuration structures and constants for gospecify
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileVersion is the current configuration file schema version
+const ConfigFileVersion = 1
+
+// configMigrations upgrade a configuration mapping by one schema version;
+// entry i migrates version i to version i+1
+var configMigrations = []func(root *yaml.Node) error{
+	migrateConfigV0,
+}
+
+// migrateConfigV0 upgrades an unversioned file. The settings are unchanged;
+// the file only gains its version key.
+func migrateConfigV0(root *yaml.Node) error {
+	return nil
+}
+
+// MigrateConfigFile upgrades configuration file contents to ConfigFileVersion,
+// keeping comments where the YAML structure allows. It returns the rewritten
+// contents and the version the file started at; contents already at the
+// current version are returned unchanged.
+func MigrateConfigFile(data []byte, path string) ([]byte, int, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, 0, errors.Wrap(errors.ErrCodeInvalidConfig, fmt.Sprintf("failed to parse config file %s", path), err)
+	}
+
+	// An empty file is an unversioned file with no settings
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, 0, errors.NewInvalidConfig(fmt.Sprintf("config file %s must contain a mapping of settings", path))
+	}
+
+	from := 0
+	versionIndex := -1
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "version" {
+			versionIndex = i
+			version, err := strconv.Atoi(root.Content[i+1].Value)
+			if err != nil {
+				return nil, 0, errors.NewInvalidConfig(
+					fmt.Sprintf("config file %s: version %q is not a number", path, root.Content[i+1].Value))
+			}
+			from = version
+		}
+	}
+
+	if from < 0 {
+		return nil, from, errors.NewInvalidConfig(fmt.Sprintf("config file %s: version %d must not be negative", path, from))
+	}
+	if from > ConfigFileVersion {
+		return nil, from, errors.NewInvalidConfig(fmt.Sprintf(
+			"config file %s has schema version %d, newer than supported version %d; upgrade gospecify",
+			path, from, ConfigFileVersion))
+	}
+	if from == ConfigFileVersion {
+		return data, from, nil
+	}
+
+	for version := from; version < ConfigFileVersion; version++ {
+		if err := configMigrations[version](root); err != nil {
+			return nil, from, errors.Wrap(errors.ErrCodeInvalidConfig,
+				fmt.Sprintf("failed to migrate config file %s from version %d", path, version), err)
+		}
+	}
+
+	versionValue := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(ConfigFileVersion)}
+	if versionIndex >= 0 {
+		root.Content[versionIndex+1] = versionValue
+	} else {
+		versionKey := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "version"}
+		// Keep a leading comment at the top of the file rather than between keys
+		if len(root.Content) > 0 {
+			versionKey.HeadComment = root.Content[0].HeadComment
+			root.Content[0].HeadComment = ""
+		}
+		root.Content = append([]*yaml.Node{versionKey, versionValue}, root.Content...)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, from, errors.Wrap(errors.ErrCodeInvalidConfig, "failed to encode migrated config file", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, from, errors.Wrap(errors.ErrCodeInvalidConfig, "failed to encode migrated config file", err)
+	}
+
+	// The result must load cleanly with the current schema
+	if _, err := ParseConfigFile(buf.Bytes(), path); err != nil {
+		return nil, from, err
+	}
+	return buf.Bytes(), from, nil
+}
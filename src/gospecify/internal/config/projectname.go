@@ -0,0 +1,126 @@
+// Package config provides configuration structures and constants for gospecify
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// manifestParser extracts a project name from the contents of a package manifest
+type manifestParser func(content []byte) string
+
+// projectManifests lists the package manifests checked for a project name, in priority order
+var projectManifests = []struct {
+	File   string
+	Parser manifestParser
+}{
+	{File: "package.json", Parser: parsePackageJSONName},
+	{File: "pyproject.toml", Parser: parsePyprojectName},
+	{File: "Cargo.toml", Parser: parseCargoName},
+	{File: "go.mod", Parser: parseGoModName},
+}
+
+// DetectProjectName returns a project name declared by a package manifest in dir,
+// or an empty string if none of the known manifests declare one
+func DetectProjectName(dir string) string {
+	for _, manifest := range projectManifests {
+		content, err := os.ReadFile(filepath.Join(dir, manifest.File))
+		if err != nil {
+			continue
+		}
+		if name := strings.TrimSpace(manifest.Parser(content)); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// parsePackageJSONName reads the "name" field of a package.json, dropping any npm scope
+func parsePackageJSONName(content []byte) string {
+	var pkg struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return ""
+	}
+
+	if strings.HasPrefix(pkg.Name, "@") {
+		if _, name, found := strings.Cut(pkg.Name, "/"); found {
+			return name
+		}
+	}
+	return pkg.Name
+}
+
+// parsePyprojectName reads [project].name, falling back to [tool.poetry].name
+func parsePyprojectName(content []byte) string {
+	if name := tomlTableString(content, "project", "name"); name != "" {
+		return name
+	}
+	return tomlTableString(content, "tool.poetry", "name")
+}
+
+// parseCargoName reads [package].name from a Cargo.toml
+func parseCargoName(content []byte) string {
+	return tomlTableString(content, "package", "name")
+}
+
+// parseGoModName returns the last element of the module path in a go.mod
+func parseGoModName(content []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if modulePath, found := strings.CutPrefix(line, "module "); found {
+			modulePath = strings.Trim(strings.TrimSpace(modulePath), `"`)
+			name := path.Base(modulePath)
+			// Skip major version suffixes such as example.com/foo/v2
+			if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+				name = path.Base(path.Dir(modulePath))
+			}
+			return name
+		}
+	}
+	return ""
+}
+
+// tomlTableString finds a simple string key within a TOML table. It only
+// understands the `key = "value"` form, which is all manifests use for names.
+func tomlTableString(content []byte, table, key string) string {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	inTable := false
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			inTable = line == "["+table+"]"
+			continue
+		}
+		if !inTable {
+			continue
+		}
+
+		k, v, found := strings.Cut(line, "=")
+		if !found || strings.TrimSpace(k) != key {
+			continue
+		}
+
+		v = strings.TrimSpace(v)
+		if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') {
+			if end := strings.IndexByte(v[1:], v[0]); end >= 0 {
+				return v[1 : end+1]
+			}
+		}
+		return ""
+	}
+
+	return ""
+}
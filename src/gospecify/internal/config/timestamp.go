@@ -0,0 +1,51 @@
+// Package config provides configuration structures and constants for gospecify
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// SourceDateEpochEnv is the reproducible-builds variable holding a fixed Unix timestamp
+const SourceDateEpochEnv = "SOURCE_DATE_EPOCH"
+
+// ResolveTimestamp returns the creation time to record in generated files. An
+// explicit value (RFC 3339 or Unix seconds) wins over SOURCE_DATE_EPOCH, which
+// wins over the current time. Fixed timestamps are returned in UTC.
+func ResolveTimestamp(explicit string) (time.Time, error) {
+	if explicit != "" {
+		if t, err := time.Parse(time.RFC3339, explicit); err == nil {
+			return t.UTC(), nil
+		}
+		t, err := parseUnixSeconds(explicit)
+		if err != nil {
+			return time.Time{}, errors.NewValidationError(
+				fmt.Sprintf("invalid --timestamp %q: expected RFC 3339 (2006-01-02T15:04:05Z) or Unix seconds", explicit))
+		}
+		return t, nil
+	}
+
+	if epoch := os.Getenv(SourceDateEpochEnv); epoch != "" {
+		t, err := parseUnixSeconds(epoch)
+		if err != nil {
+			return time.Time{}, errors.NewInvalidConfig(
+				fmt.Sprintf("invalid %s %q: expected Unix seconds", SourceDateEpochEnv, epoch))
+		}
+		return t, nil
+	}
+
+	return time.Now(), nil
+}
+
+// parseUnixSeconds parses a non-negative count of seconds since the Unix epoch
+func parseUnixSeconds(value string) (time.Time, error) {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		return time.Time{}, fmt.Errorf("not a Unix timestamp: %s", value)
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
@@ -2,24 +2,76 @@
 package config
 
 import (
+	"log/slog"
 	"sync"
 	"time"
 )
 
 // ProjectConfig holds the configuration for a project initialization
 type ProjectConfig struct {
-	Name        string    `json:"name"`
-	Path        string    `json:"path"`
-	AIAssistant string    `json:"ai_assistant"`
-	ScriptType  string    `json:"script_type"`
-	NoGit       bool      `json:"no_git"`
-	Force       bool      `json:"force"`
-	IgnoreTools bool      `json:"ignore_tools"`
-	SkipTLS     bool      `json:"skip_tls"`
-	Debug       bool      `json:"debug"`
-	GitHubToken string    `json:"github_token,omitempty"`
-	Here        bool      `json:"here"`
-	CreatedAt   time.Time `json:"created_at"`
+	Name                  string            `json:"name"`
+	Path                  string            `json:"path"`
+	AIAssistant           string            `json:"ai_assistant"`
+	ScriptType            string            `json:"script_type"`
+	NoGit                 bool              `json:"no_git"`
+	Force                 bool              `json:"force"`
+	IgnoreTools           bool              `json:"ignore_tools"`
+	SkipTLS               bool              `json:"skip_tls"`
+	Debug                 bool              `json:"debug"`
+	LogLevel              string            `json:"log_level"`
+	GitHubToken           string            `json:"github_token,omitempty"`
+	Here                  bool              `json:"here"`
+	CompactProgress       bool              `json:"compact_progress"`
+	Branch                string            `json:"branch"`
+	GitCommitMessage      string            `json:"git_commit_message,omitempty"`
+	GitAuthor             string            `json:"git_author,omitempty"`
+	NoGitChmod            bool              `json:"no_git_chmod"`
+	TemplateSet           string            `json:"template_set"`
+	CleanBefore           bool              `json:"clean_before"`
+	Describe              string            `json:"describe,omitempty"`
+	RetryStep             string            `json:"retry_step,omitempty"`
+	CopyScriptsToAgent    bool              `json:"copy_scripts_to_agent"`
+	KeepTemplateStructure bool              `json:"keep_template_structure"`
+	Owner                 string            `json:"owner,omitempty"`
+	Values                map[string]string `json:"values,omitempty"`
+	WriteConcurrency      int               `json:"write_concurrency"`
+	WithEditorConfig      bool              `json:"with_editor_config"`
+	Accessible            bool              `json:"accessible"`
+	CommandsOnly          bool              `json:"commands_only"`
+	Verify                bool              `json:"verify"`
+	TempDir               string            `json:"temp_dir,omitempty"`
+	Proxy                 string            `json:"proxy,omitempty"`
+	GitHubAPIURL          string            `json:"github_api_url,omitempty"`
+	Mirror                string            `json:"mirror,omitempty"`
+	NoCache               bool              `json:"no_cache"`
+	CacheTTL              time.Duration     `json:"cache_ttl,omitempty"`
+	OutputFormat          string            `json:"output_format"`
+	DryRun                bool              `json:"dry_run"`
+	NonInteractive        bool              `json:"non_interactive"`
+	ShowTree              bool              `json:"show_tree"`
+	FixLineEndings        bool              `json:"fix_line_endings"`
+	From                  string            `json:"from,omitempty"`
+	Ref                   string            `json:"ref,omitempty"`
+	TemplateRepo          string            `json:"template_repo,omitempty"`
+	TemplateRef           string            `json:"template_ref,omitempty"`
+	TemplateDir           string            `json:"template_dir,omitempty"`
+	UseRelease            bool              `json:"use_release"`
+	AllowSymlinks         bool              `json:"allow_symlinks"`
+	Commit                bool              `json:"commit"`
+	Commands              []string          `json:"commands,omitempty"`
+	Timeout               time.Duration     `json:"timeout,omitempty"`
+	RollbackOnError       bool              `json:"rollback_on_error"`
+	Quiet                 bool              `json:"quiet"`
+	Gitignore             bool              `json:"gitignore"`
+	UI                    UIConfig          `json:"-"`
+	Logger                *slog.Logger      `json:"-"`
+	CreatedAt             time.Time         `json:"created_at"`
+}
+
+// UIConfig holds presentation options for terminal output
+type UIConfig struct {
+	// Border is the panel border style: rounded (default), thick, or none
+	Border string `yaml:"border,omitempty"`
 }
 
 // StepTracker manages hierarchical progress tracking with live updates
@@ -28,6 +80,7 @@ type StepTracker struct {
 	Steps       []Step         `json:"steps"`
 	StatusOrder map[string]int `json:"-"`
 	refreshCb   func()         `json:"-"`
+	listeners   []func(Step)   `json:"-"`
 	mu          sync.RWMutex   `json:"-"`
 }
 
@@ -39,6 +92,9 @@ type Step struct {
 	Detail  string    `json:"detail"`
 	Started time.Time `json:"started"`
 	Ended   time.Time `json:"ended"`
+	// Current and Total carry optional sub-progress, e.g. 3 of 12 files written
+	Current int `json:"current,omitempty"`
+	Total   int `json:"total,omitempty"`
 }
 
 // Status represents the status of a step
@@ -52,17 +108,24 @@ const (
 	StatusSkipped Status = "skipped"
 )
 
-// AttachRefresh attaches a callback function for live UI updates
+// AttachRefresh attaches a callback function for live UI updates. It runs
+// after every change, outside the tracker's lock, so it may read the tracker.
 func (st *StepTracker) AttachRefresh(cb func()) {
 	st.mu.Lock()
 	defer st.mu.Unlock()
 	st.refreshCb = cb
 }
 
+// AttachListener registers a callback that receives a copy of each step whenever it changes
+func (st *StepTracker) AttachListener(cb func(Step)) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.listeners = append(st.listeners, cb)
+}
+
 // Add adds a new step to the tracker
 func (st *StepTracker) Add(key, label string) {
 	st.mu.Lock()
-	defer st.mu.Unlock()
 
 	if st.StatusOrder == nil {
 		st.StatusOrder = map[string]int{
@@ -77,6 +140,7 @@ func (st *StepTracker) Add(key, label string) {
 	// Check if step already exists
 	for _, step := range st.Steps {
 		if step.Key == key {
+			st.mu.Unlock()
 			return
 		}
 	}
@@ -87,7 +151,9 @@ func (st *StepTracker) Add(key, label string) {
 		Status: StatusPending,
 	})
 
-	st.maybeRefresh()
+	refresh := st.refreshCb
+	st.mu.Unlock()
+	runRefresh(refresh)
 }
 
 // Start marks a step as running
@@ -110,11 +176,43 @@ func (st *StepTracker) Skip(key, detail string) {
 	st.update(key, StatusSkipped, detail)
 }
 
+// Progress records sub-progress for a step without changing its status
+func (st *StepTracker) Progress(key string, current, total int) {
+	st.mu.Lock()
+
+	var changed Step
+	found := false
+	for i := range st.Steps {
+		if st.Steps[i].Key == key {
+			st.Steps[i].Current = current
+			st.Steps[i].Total = total
+			changed = st.Steps[i]
+			found = true
+			break
+		}
+	}
+	if !found {
+		st.mu.Unlock()
+		return
+	}
+
+	refresh := st.refreshCb
+	listeners := append([]func(Step){}, st.listeners...)
+	st.mu.Unlock()
+
+	runRefresh(refresh)
+
+	for _, listener := range listeners {
+		listener(changed)
+	}
+}
+
 // update updates a step's status and detail
 func (st *StepTracker) update(key string, status Status, detail string) {
 	st.mu.Lock()
-	defer st.mu.Unlock()
 
+	var changed Step
+	found := false
 	for i := range st.Steps {
 		if st.Steps[i].Key == key {
 			st.Steps[i].Status = status
@@ -127,24 +225,38 @@ func (st *StepTracker) update(key string, status Status, detail string) {
 			if (status == StatusDone || status == StatusError || status == StatusSkipped) && st.Steps[i].Ended.IsZero() {
 				st.Steps[i].Ended = time.Now()
 			}
-			st.maybeRefresh()
-			return
+			changed = st.Steps[i]
+			found = true
+			break
 		}
 	}
 
 	// If not found, add it
-	st.Steps = append(st.Steps, Step{
-		Key:    key,
-		Label:  key,
-		Status: status,
-		Detail: detail,
-	})
-	st.maybeRefresh()
+	if !found {
+		changed = Step{
+			Key:    key,
+			Label:  key,
+			Status: status,
+			Detail: detail,
+		}
+		st.Steps = append(st.Steps, changed)
+	}
+
+	refresh := st.refreshCb
+	listeners := append([]func(Step){}, st.listeners...)
+	st.mu.Unlock()
+
+	runRefresh(refresh)
+
+	// Notify listeners outside the lock so they can read the tracker
+	for _, listener := range listeners {
+		listener(changed)
+	}
 }
 
-// maybeRefresh calls the refresh callback if set
-func (st *StepTracker) maybeRefresh() {
-	if st.refreshCb != nil {
+// runRefresh calls the refresh callback if set
+func runRefresh(refresh func()) {
+	if refresh != nil {
 		func() {
 			defer func() {
 				if r := recover(); r != nil {
@@ -152,7 +264,7 @@ func (st *StepTracker) maybeRefresh() {
 					_ = r
 				}
 			}()
-			st.refreshCb()
+			refresh()
 		}()
 	}
 }
@@ -171,3 +283,36 @@ func (st *StepTracker) GetSteps() []Step {
 func (st *StepTracker) GetTitle() string {
 	return st.Title
 }
+
+// InitResult summarizes a completed project initialization
+type InitResult struct {
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Here        bool   `json:"here"`
+	AIAssistant string `json:"ai_assistant"`
+	ScriptType  string `json:"script_type"`
+	// AIAssistants lists every assistant scaffolded, which differs from
+	// AIAssistant only for --ai all
+	AIAssistants []string `json:"ai_assistants,omitempty"`
+	// DryRun is set when nothing was written
+	DryRun bool `json:"dry_run,omitempty"`
+	// Files lists the project-relative paths generated, when requested
+	Files []string `json:"files,omitempty"`
+	// Git describes what happened to the git repository, e.g. "initialized"
+	Git string `json:"git,omitempty"`
+	// Remote is the URL of the existing repository's origin remote, if any
+	Remote string `json:"remote,omitempty"`
+	// Commands lists the commands installed with --commands; empty means all
+	Commands []string `json:"commands,omitempty"`
+	// SlashCommands lists the installed commands in name order, described
+	// by their templates
+	SlashCommands []SlashCommand `json:"slash_commands,omitempty"`
+	// Gitignored lists the agent folders --gitignore made sure .gitignore covers
+	Gitignored []string `json:"gitignored,omitempty"`
+}
+
+// SlashCommand is a command installed for the AI assistant
+type SlashCommand struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
@@ -0,0 +1,63 @@
+// Package diskspace reports free disk space in a platform-independent way
+package diskspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// fileOverhead approximates the block allocated to each file beyond its content
+const fileOverhead = 4096
+
+// Available returns the bytes available to the current user on the filesystem
+// holding path. If path does not exist yet, its nearest existing parent is used.
+func Available(path string) (uint64, error) {
+	dir, err := existingAncestor(path)
+	if err != nil {
+		return 0, err
+	}
+	return available(dir)
+}
+
+// Estimate returns the space needed to write files with the given sizes
+func Estimate(sizes ...int64) uint64 {
+	var total uint64
+	for _, size := range sizes {
+		total += uint64(size) + fileOverhead
+	}
+	return total
+}
+
+// Check fails with a clear message if path lacks room for required bytes
+func Check(path string, required uint64) error {
+	free, err := Available(path)
+	if err != nil {
+		return err
+	}
+	if free < required {
+		return errors.NewInsufficientDiskSpace(path, required, free)
+	}
+	return nil
+}
+
+// existingAncestor walks up from path until it finds something that exists
+func existingAncestor(path string) (string, error) {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrCodeFileSystemError, "failed to resolve path", err)
+	}
+
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New(errors.ErrCodeFileSystemError, fmt.Sprintf("no existing parent directory for %s", path))
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+
+// Package diskspace reports free disk space in a platform-independent way
+package diskspace
+
+import (
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// available queries statfs for the blocks available to unprivileged users
+func available(dir string) (uint64, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(dir, &stat); err != nil {
+		return 0, errors.Wrap(errors.ErrCodeFileSystemError, "failed to query free disk space", err)
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,23 @@
+//go:build windows
+
+// Package diskspace reports free disk space in a platform-independent way
+package diskspace
+
+import (
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+	"golang.org/x/sys/windows"
+)
+
+// available queries GetDiskFreeSpaceEx for the bytes available to the caller
+func available(dir string) (uint64, error) {
+	dirPtr, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return 0, errors.Wrap(errors.ErrCodeFileSystemError, "invalid path", err)
+	}
+
+	var freeBytes uint64
+	if err := windows.GetDiskFreeSpaceEx(dirPtr, &freeBytes, nil, nil); err != nil {
+		return 0, errors.Wrap(errors.ErrCodeFileSystemError, "failed to query free disk space", err)
+	}
+	return freeBytes, nil
+}
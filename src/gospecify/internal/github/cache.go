@@ -0,0 +1,107 @@
+// Package github provides GitHub API integration
+package github
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// releaseCache stores release lookups on disk so repeated runs reuse them
+// instead of spending the API rate limit
+type releaseCache struct {
+	dir string
+	ttl time.Duration
+	// refresh skips fresh entries, always asking the API (with the ETag)
+	refresh bool
+}
+
+// cachedRelease is the on-disk form of a cached release lookup
+type cachedRelease struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	ETag      string    `json:"etag,omitempty"`
+	Release   Release   `json:"release"`
+}
+
+// WithReleaseCache caches release lookups in dir. Entries younger than ttl
+// are used without a request; older ones are revalidated with their ETag, so
+// an unchanged release costs a 304 Not Modified. With refresh set entries
+// are always revalidated, though the result is still stored.
+func WithReleaseCache(dir string, ttl time.Duration, refresh bool) ClientOption {
+	return func(c *Client) {
+		c.cache = &releaseCache{dir: dir, ttl: ttl, refresh: refresh}
+	}
+}
+
+// DefaultCacheDir returns the directory release lookups are cached in below
+// the user cache directory, or "" when the platform has none
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gospecify", "releases")
+}
+
+// path returns the cache file of a lookup, keyed by API host, owner/repo and tag
+func (rc *releaseCache) path(baseURL, owner, repo, tag string) string {
+	host := "api"
+	if parsed, err := url.Parse(baseURL); err == nil && parsed.Host != "" {
+		// Ports would put a colon in the path, which Windows rejects
+		host = strings.ReplaceAll(parsed.Host, ":", "_")
+	}
+	return filepath.Join(rc.dir, cacheSegment(host), cacheSegment(owner), cacheSegment(repo), cacheSegment(ReleaseName(tag))+".json")
+}
+
+// cacheSegment escapes s into a single file name, so a tag such as
+// "v1/beta" or "../x" can neither nest directories nor leave the cache
+func cacheSegment(s string) string {
+	escaped := strings.ReplaceAll(url.PathEscape(s), ":", "%3A")
+	if escaped == "" || escaped == "." || escaped == ".." {
+		return strings.ReplaceAll(escaped, ".", "%2E") + "_"
+	}
+	return escaped
+}
+
+// load returns the cached lookup at path, or nil when there is none usable
+func (rc *releaseCache) load(path string) *cachedRelease {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var entry cachedRelease
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil
+	}
+	return &entry
+}
+
+// fresh reports whether entry may be used without asking the API
+func (rc *releaseCache) fresh(entry *cachedRelease) bool {
+	return !rc.refresh && time.Since(entry.FetchedAt) < rc.ttl
+}
+
+// store writes entry to path; failures only cost a request next time
+func (rc *releaseCache) store(path string, entry *cachedRelease) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	// Rename a private temporary file so concurrent runs never read, or
+	// write into, a half-written entry
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil || os.Chmod(tmp.Name(), 0644) != nil || os.Rename(tmp.Name(), path) != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
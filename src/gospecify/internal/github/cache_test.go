@@ -0,0 +1,36 @@
+package github
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReleaseCachePathStaysInCache(t *testing.T) {
+	rc := &releaseCache{dir: t.TempDir()}
+	repoDir := filepath.Join(rc.dir, "api.github.com", "github", "spec-kit")
+	for _, tag := range []string{"", "v1.2.0", "v1/beta", "../../x", "..", `..\x`, "a:b"} {
+		path := rc.path("https://api.github.com", "github", "spec-kit", tag)
+		if filepath.Dir(path) != repoDir || strings.ContainsAny(filepath.Base(path), `/\:`) {
+			t.Errorf("tag %q is cached at %s, want a single file in %s", tag, path, repoDir)
+		}
+	}
+	if a, b := rc.path("", "o", "r", "v1/beta"), rc.path("", "o", "r", "v1%2Fbeta"); a == b {
+		t.Errorf("distinct tags share the cache file %s", a)
+	}
+}
+
+func TestReleaseCacheStoreAndLoad(t *testing.T) {
+	rc := &releaseCache{dir: t.TempDir(), ttl: time.Hour}
+	path := rc.path("https://api.github.com", "github", "spec-kit", "v1/beta")
+	rc.store(path, &cachedRelease{FetchedAt: time.Now(), ETag: `"abc"`, Release: Release{TagName: "v1/beta"}})
+
+	entry := rc.load(path)
+	if entry == nil || entry.Release.TagName != "v1/beta" || !rc.fresh(entry) {
+		t.Fatalf("load() = %+v, want the fresh stored entry", entry)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(filepath.Dir(path), "*.tmp")); len(matches) != 0 {
+		t.Errorf("temporary files left behind: %v", matches)
+	}
+}
@@ -3,12 +3,19 @@ package github
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
+	"os/exec"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
@@ -33,34 +40,111 @@ type Client struct {
 	httpClient *http.Client
 	token      string
 	baseURL    string
+	retries    int
+	backoff    time.Duration
+	proxy      *url.URL
+	mirror     *url.URL
+	cache      *releaseCache
+	logger     *slog.Logger
 }
 
-// NewClient creates a new GitHub API client
-func NewClient(token string, skipTLS bool) *Client {
-	client := &http.Client{
-		Timeout: 30 * time.Second,
+// NewClient creates a new GitHub API client. Requests are retried with
+// DefaultRetries and DefaultBackoff unless options say otherwise, and go
+// through the proxy named by HTTPS_PROXY, HTTP_PROXY and NO_PROXY unless
+// WithProxy overrides it. Nothing is logged unless WithLogger is given.
+func NewClient(token string, skipTLS bool, opts ...ClientOption) *Client {
+	c := &Client{
+		token:   token,
+		baseURL: config.GitHubAPI,
+		retries: DefaultRetries,
+		backoff: DefaultBackoff,
+		logger:  slog.New(slog.DiscardHandler),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
 
+	// Cloning the default transport keeps its proxy and timeout settings
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if c.proxy != nil {
+		transport.Proxy = http.ProxyURL(c.proxy)
+	}
 	if skipTLS {
-		tr := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
-		client.Transport = tr
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	c.httpClient = &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: transport,
+	}
+	return c
+}
+
+// WithProxy sends every request through proxyURL, ignoring the proxy environment variables
+func WithProxy(proxyURL *url.URL) ClientOption {
+	return func(c *Client) {
+		c.proxy = proxyURL
 	}
+}
+
+// WithBaseURL sends API requests to apiURL, such as a GitHub Enterprise
+// https://HOST/api/v3 endpoint, instead of config.GitHubAPI
+func WithBaseURL(apiURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(apiURL, "/")
+	}
+}
 
-	return &Client{
-		httpClient: client,
-		token:      token,
-		baseURL:    config.GitHubAPI,
+// WithMirror downloads release assets hosted on github.com from mirror
+// instead, keeping the asset path below the mirror's own path
+func WithMirror(mirror *url.URL) ClientOption {
+	return func(c *Client) {
+		c.mirror = mirror
+	}
+}
+
+// downloadURL returns where asset is fetched from, honoring WithMirror
+func (c *Client) downloadURL(asset ReleaseAsset) string {
+	if c.mirror == nil {
+		return asset.BrowserDownloadURL
+	}
+	assetURL, err := url.Parse(asset.BrowserDownloadURL)
+	if err != nil || !strings.EqualFold(assetURL.Host, config.GitHubDownloadHost) {
+		return asset.BrowserDownloadURL
 	}
+	mirrored := *c.mirror
+	mirrored.Path = strings.TrimSuffix(c.mirror.Path, "/") + assetURL.Path
+	mirrored.RawPath = ""
+	mirrored.RawQuery = assetURL.RawQuery
+	return mirrored.String()
 }
 
 // GetLatestRelease gets the latest release for the spec-kit repository
 func (c *Client) GetLatestRelease(ctx context.Context) (*Release, error) {
-	url := fmt.Sprintf("%s/repos/%s/%s/releases/latest",
-		c.baseURL, config.GitHubOwner, config.GitHubRepo)
+	return c.GetRelease(ctx, config.GitHubOwner, config.GitHubRepo, "")
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+// GetRelease gets the release tagged tag in owner/repo, or the latest release
+// when tag is empty, going through the WithReleaseCache cache if there is one
+func (c *Client) GetRelease(ctx context.Context, owner, repo, tag string) (*Release, error) {
+	var cachePath string
+	var cached *cachedRelease
+	if c.cache != nil {
+		cachePath = c.cache.path(c.baseURL, owner, repo, tag)
+		cached = c.cache.load(cachePath)
+		if cached != nil && c.cache.fresh(cached) {
+			c.logger.Debug("using cached release", "repository", owner+"/"+repo, "release", ReleaseName(tag), "fetched", cached.FetchedAt)
+			return &cached.Release, nil
+		}
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/releases/latest", c.baseURL, owner, repo)
+	if tag != "" {
+		endpoint = fmt.Sprintf("%s/repos/%s/%s/releases/tags/%s", c.baseURL, owner, repo, url.PathEscape(tag))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, errors.Wrap(errors.ErrCodeNetworkError, "failed to create request", err)
 	}
@@ -70,13 +154,26 @@ func (c *Client) GetLatestRelease(ctx context.Context) (*Release, error) {
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("User-Agent", config.UserAgent)
+	if cached != nil && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
-		return nil, errors.Wrap(errors.ErrCodeNetworkError, "failed to get latest release", err)
+		return nil, errors.Wrap(errors.ErrCodeNetworkError, "failed to get release", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	// Not Modified does not count against the rate limit
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		cached.FetchedAt = time.Now()
+		c.cache.store(cachePath, cached)
+		return &cached.Release, nil
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.NewGitHubAPIError(
+			fmt.Sprintf("no release %s found in %s/%s", ReleaseName(tag), owner, repo), nil)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, errors.NewGitHubAPIError(
 			fmt.Sprintf("GitHub API returned %d", resp.StatusCode), nil)
@@ -87,51 +184,93 @@ func (c *Client) GetLatestRelease(ctx context.Context) (*Release, error) {
 		return nil, errors.Wrap(errors.ErrCodeGitHubAPIError, "failed to decode release", err)
 	}
 
+	if c.cache != nil {
+		c.cache.store(cachePath, &cachedRelease{FetchedAt: time.Now(), ETag: resp.Header.Get("ETag"), Release: release})
+	}
+
 	return &release, nil
 }
 
-// DownloadAsset downloads a release asset to the specified path
-func (c *Client) DownloadAsset(ctx context.Context, asset ReleaseAsset, destPath string, progressFn func(int64, int64)) error {
-	req, err := http.NewRequestWithContext(ctx, "GET", asset.BrowserDownloadURL, nil)
-	if err != nil {
-		return errors.Wrap(errors.ErrCodeNetworkError, "failed to create download request", err)
+// ReleaseName describes tag for messages, with the empty tag meaning the latest release
+func ReleaseName(tag string) string {
+	if tag == "" {
+		return "latest"
 	}
+	return tag
+}
 
-	if c.token != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+// checksumSuffix names the sibling asset holding a release asset's SHA-256
+const checksumSuffix = ".sha256"
+
+// DownloadAsset downloads a release asset to the specified path. When
+// expectedSHA256 is non-empty the digest of the streamed bytes must match it,
+// otherwise the file is removed and an error returned; pass "" to skip the
+// check for releases that publish no checksums.
+//
+// If destPath already holds part of the asset from an interrupted download,
+// only the remaining bytes are requested with a Range header and appended;
+// when the server ignores the range the download restarts from scratch.
+// progressFn is called with offsets that include the resumed bytes.
+func (c *Client) DownloadAsset(ctx context.Context, asset ReleaseAsset, destPath, expectedSHA256 string, progressFn func(int64, int64)) error {
+	var offset int64
+	if info, err := os.Stat(destPath); err == nil && info.Mode().IsRegular() &&
+		info.Size() > 0 && (asset.Size <= 0 || info.Size() < asset.Size) {
+		offset = info.Size()
 	}
-	req.Header.Set("User-Agent", config.UserAgent)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.getAsset(ctx, asset, offset)
 	if err != nil {
-		return errors.Wrap(errors.ErrCodeNetworkError, "failed to download asset", err)
+		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusPartialContent {
+		c.logger.Info("resuming download", "asset", asset.Name, "offset", offset)
+	}
+	c.logger.Debug("downloading asset", "asset", asset.Name, "path", destPath)
 
-	if resp.StatusCode != http.StatusOK {
-		return errors.NewGitHubAPIError(
-			fmt.Sprintf("download failed with status %d", resp.StatusCode), nil)
+	hash := sha256.New()
+	var written int64
+	total := resp.ContentLength
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+
+	if resp.StatusCode == http.StatusPartialContent {
+		// The digest covers the whole asset, so the bytes already on disk are hashed first
+		existing, err := os.Open(destPath)
+		if err != nil {
+			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to open partial download", err)
+		}
+		_, err = io.Copy(hash, io.LimitReader(existing, offset))
+		_ = existing.Close()
+		if err != nil {
+			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to read partial download", err)
+		}
+
+		flags = os.O_WRONLY | os.O_APPEND
+		written = offset
+		if total >= 0 {
+			total += offset
+		}
 	}
 
-	file, err := os.Create(destPath)
+	file, err := os.OpenFile(destPath, flags, 0644)
 	if err != nil {
 		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to create destination file", err)
 	}
 	defer func() { _ = file.Close() }()
 
-	var written int64
 	buffer := make([]byte, 32*1024) // 32KB buffer
 
 	for {
 		n, readErr := resp.Body.Read(buffer)
 		if n > 0 {
 			written += int64(n)
+			hash.Write(buffer[:n])
 			if _, writeErr := file.Write(buffer[:n]); writeErr != nil {
 				return errors.Wrap(errors.ErrCodeFileSystemError, "failed to write to file", writeErr)
 			}
 
 			if progressFn != nil {
-				progressFn(written, resp.ContentLength)
+				progressFn(written, total)
 			}
 		}
 
@@ -143,11 +282,168 @@ func (c *Client) DownloadAsset(ctx context.Context, asset ReleaseAsset, destPath
 		}
 	}
 
+	if expectedSHA256 == "" {
+		return nil
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(actual, expectedSHA256) {
+		_ = file.Close()
+		_ = os.Remove(destPath)
+		return errors.NewGitHubAPIError(fmt.Sprintf(
+			"checksum mismatch for %s: expected SHA-256 %s, got %s (%d bytes downloaded)",
+			asset.Name, strings.ToLower(expectedSHA256), actual, written), nil)
+	}
+
 	return nil
 }
 
-// GetGitHubToken retrieves the GitHub token from environment or CLI
-func GetGitHubToken(cliToken string) string {
+// ReleaseChecksum returns the SHA-256 published for asset in a sibling
+// "<name>.sha256" asset of the same release. The second return value is
+// false when the release has no such asset.
+func (c *Client) ReleaseChecksum(ctx context.Context, release *Release, asset ReleaseAsset) (string, bool, error) {
+	for _, sibling := range release.Assets {
+		if sibling.Name != asset.Name+checksumSuffix {
+			continue
+		}
+
+		resp, err := c.getAsset(ctx, sibling, 0)
+		if err != nil {
+			return "", false, err
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		// sha256sum format: "<digest>  <file name>"; a bare digest is accepted too
+		data, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if err != nil {
+			return "", false, errors.Wrap(errors.ErrCodeNetworkError, "failed to read checksum", err)
+		}
+		fields := strings.Fields(string(data))
+		if len(fields) == 0 || len(fields[0]) != sha256.Size*2 {
+			return "", false, errors.NewGitHubAPIError(fmt.Sprintf("%s does not contain a SHA-256 digest", sibling.Name), nil)
+		}
+		if _, err := hex.DecodeString(fields[0]); err != nil {
+			return "", false, errors.NewGitHubAPIError(fmt.Sprintf("%s does not contain a SHA-256 digest", sibling.Name), err)
+		}
+		return fields[0], true, nil
+	}
+
+	return "", false, nil
+}
+
+// tokenHost reports whether the token may be sent to u: the API host, or
+// github.com where release assets are hosted
+func (c *Client) tokenHost(u *url.URL) bool {
+	if strings.EqualFold(u.Hostname(), config.GitHubDownloadHost) {
+		return true
+	}
+	apiURL, err := url.Parse(c.baseURL)
+	return err == nil && strings.EqualFold(u.Host, apiURL.Host)
+}
+
+// getAsset starts the download of a release asset, from byte offset when it is
+// positive; the response is 206 Partial Content only if the server honored the range
+func (c *Client) getAsset(ctx context.Context, asset ReleaseAsset, offset int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.downloadURL(asset), nil)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeNetworkError, "failed to create download request", err)
+	}
+
+	// A --mirror is a third party, so the token is only sent to GitHub itself
+	if c.token != "" && c.tokenHost(req.URL) {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	}
+	req.Header.Set("User-Agent", config.UserAgent)
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeNetworkError, "failed to download asset", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return resp, nil
+	case offset > 0 && resp.StatusCode == http.StatusPartialContent &&
+		strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)):
+		return resp, nil
+	case offset > 0 && (resp.StatusCode == http.StatusPartialContent || resp.StatusCode == http.StatusRequestedRangeNotSatisfiable):
+		// The partial file does not line up with the asset, so start over
+		_ = resp.Body.Close()
+		return c.getAsset(ctx, asset, 0)
+	}
+
+	_ = resp.Body.Close()
+	return nil, errors.NewGitHubAPIError(
+		fmt.Sprintf("download failed with status %d", resp.StatusCode), nil)
+}
+
+// GetTokenScopes returns the OAuth scopes granted to the client's token, as
+// reported by the X-OAuth-Scopes header. The second return value is false when
+// the header is absent, as for fine-grained and GitHub App tokens, whose
+// permissions cannot be inspected this way.
+func (c *Client) GetTokenScopes(ctx context.Context) ([]string, bool, error) {
+	if c.token == "" {
+		return nil, false, nil
+	}
+
+	// rate_limit does not count against the rate limit itself
+	url := fmt.Sprintf("%s/rate_limit", c.baseURL)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, false, errors.Wrap(errors.ErrCodeNetworkError, "failed to create request", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", config.UserAgent)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, false, errors.Wrap(errors.ErrCodeNetworkError, "failed to query token scopes", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, false, errors.NewGitHubAPIError("GitHub token is invalid or expired", nil)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, errors.NewGitHubAPIError(
+			fmt.Sprintf("GitHub API returned %d", resp.StatusCode), nil)
+	}
+
+	header, present := resp.Header["X-Oauth-Scopes"]
+	if !present {
+		return nil, false, nil
+	}
+
+	var scopes []string
+	for _, value := range header {
+		for _, scope := range strings.Split(value, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+
+	return scopes, true, nil
+}
+
+// HasScope reports whether scope is among the granted scopes
+func HasScope(scopes []string, scope string) bool {
+	for _, granted := range scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// GetGitHubToken retrieves the GitHub token from the CLI flag, the
+// environment, or failing those the gh CLI's login for the host of apiURL
+// (github.com when apiURL is empty)
+func GetGitHubToken(cliToken, apiURL string) string {
 	if cliToken != "" {
 		return cliToken
 	}
@@ -160,5 +456,57 @@ func GetGitHubToken(cliToken string) string {
 		return token
 	}
 
-	return ""
+	return ghAuthToken(ghHost(apiURL))
+}
+
+// ghHost returns the host gh keeps the login for apiURL under: github.com
+// for the public API, otherwise the GitHub Enterprise host itself
+func ghHost(apiURL string) string {
+	if apiURL == "" {
+		return config.GitHubDownloadHost
+	}
+	u, err := url.Parse(apiURL)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+	if defaultAPI, err := url.Parse(config.GitHubAPI); err == nil && strings.EqualFold(u.Host, defaultAPI.Host) {
+		return config.GitHubDownloadHost
+	}
+	return u.Host
+}
+
+// ghAuthTimeout bounds how long gh may take to print its token
+const ghAuthTimeout = 5 * time.Second
+
+var (
+	ghAuthMu     sync.Mutex
+	ghAuthTokens = make(map[string]string)
+)
+
+// ghAuthToken returns the token gh is logged in to host with, asking gh
+// only once per host and run, or "" when gh is not installed or not
+// authenticated there
+func ghAuthToken(host string) string {
+	if host == "" {
+		return ""
+	}
+	ghAuthMu.Lock()
+	defer ghAuthMu.Unlock()
+	if token, asked := ghAuthTokens[host]; asked {
+		return token
+	}
+	ghAuthTokens[host] = ""
+	if _, err := exec.LookPath("gh"); err != nil {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ghAuthTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, "gh", "auth", "token", "--hostname", host).Output()
+	if err != nil {
+		// gh exits non-zero when nobody is logged in
+		return ""
+	}
+	ghAuthTokens[host] = strings.TrimSpace(string(output))
+	return ghAuthTokens[host]
 }
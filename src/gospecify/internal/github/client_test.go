@@ -0,0 +1,18 @@
+package github
+
+import "testing"
+
+func TestGhHost(t *testing.T) {
+	for apiURL, want := range map[string]string{
+		"":                                    "github.com",
+		"https://api.github.com":              "github.com",
+		"https://API.GitHub.com/":             "github.com",
+		"https://github.example.com/api/v3":   "github.example.com",
+		"https://github.example.com:8443/api": "github.example.com:8443",
+		"not a url with a host":               "",
+	} {
+		if got := ghHost(apiURL); got != want {
+			t.Errorf("ghHost(%q) = %q, want %q", apiURL, got, want)
+		}
+	}
+}
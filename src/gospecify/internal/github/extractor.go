@@ -5,25 +5,48 @@ import (
 	"archive/zip"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/diskspace"
 	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
 )
 
 // Extractor handles template extraction from zip archives
 type Extractor struct {
-	destDir string
+	destDir       string
+	allowSymlinks bool
+	logger        *slog.Logger
+	// symlinks holds the destDir-relative paths of the symlinks extracted so far
+	symlinks map[string]bool
 }
 
 // NewExtractor creates a new template extractor
 func NewExtractor(destDir string) *Extractor {
 	return &Extractor{
 		destDir: destDir,
+		logger:  slog.New(slog.DiscardHandler),
 	}
 }
 
+// WithSymlinks allows symlink entries whose targets stay inside the
+// destination directory; by default any symlink in an archive is an error
+func (e *Extractor) WithSymlinks(allow bool) *Extractor {
+	e.allowSymlinks = allow
+	return e
+}
+
+// WithLogger logs each extracted path at debug level
+func (e *Extractor) WithLogger(logger *slog.Logger) *Extractor {
+	if logger != nil {
+		e.logger = logger
+	}
+	return e
+}
+
 // ExtractZip extracts a zip archive to the destination directory
 func (e *Extractor) ExtractZip(zipPath string, progressFn func(int64, int64)) error {
 	reader, err := zip.OpenReader(zipPath)
@@ -34,11 +57,23 @@ func (e *Extractor) ExtractZip(zipPath string, progressFn func(int64, int64)) er
 
 	// Calculate total size for progress
 	var totalSize int64
+	sizes := make([]int64, 0, len(reader.File))
 	for _, file := range reader.File {
 		totalSize += int64(file.UncompressedSize64)
+		sizes = append(sizes, int64(file.UncompressedSize64))
+	}
+
+	// Fail before writing anything rather than partway through
+	if err := diskspace.Check(e.destDir, diskspace.Estimate(sizes...)); err != nil {
+		if errors.CodeOf(err) == errors.ErrCodeDiskSpace {
+			return err
+		}
+		// Not being able to measure free space shouldn't block extraction
+		e.logger.Warn("skipping disk space check", "error", err)
 	}
 
 	var extractedSize int64
+	e.symlinks = make(map[string]bool)
 
 	// Extract files
 	for _, file := range reader.File {
@@ -52,19 +87,17 @@ func (e *Extractor) ExtractZip(zipPath string, progressFn func(int64, int64)) er
 
 // extractFile extracts a single file from the zip archive
 func (e *Extractor) extractFile(file *zip.File, extractedSize *int64, totalSize int64, progressFn func(int64, int64)) error {
-	// Open the file in the zip
-	src, err := file.Open()
+	// Construct destination path, refusing entries that would land outside destDir (Zip Slip)
+	destPath, err := e.destPath(file.Name)
 	if err != nil {
-		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to open file in zip", err)
+		return err
 	}
-	defer func() { _ = src.Close() }()
 
-	// Construct destination path
-	destPath := filepath.Join(e.destDir, file.Name)
+	e.logger.Debug("extracting", "entry", file.Name, "path", destPath)
 
 	// Create directory if needed
 	if file.FileInfo().IsDir() {
-		if err := os.MkdirAll(destPath, file.Mode()); err != nil {
+		if err := os.MkdirAll(destPath, file.Mode().Perm()|0700); err != nil {
 			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to create directory", err)
 		}
 		return nil
@@ -75,8 +108,19 @@ func (e *Extractor) extractFile(file *zip.File, extractedSize *int64, totalSize
 		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to create parent directory", err)
 	}
 
-	// Create destination file
-	dest, err := os.OpenFile(destPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode())
+	// Open the file in the zip
+	src, err := file.Open()
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to open file in zip", err)
+	}
+	defer func() { _ = src.Close() }()
+
+	if file.Mode()&os.ModeSymlink != 0 {
+		return e.extractSymlink(file.Name, destPath, src)
+	}
+
+	// Create destination file, keeping only the permission bits of the recorded mode
+	dest, err := os.OpenFile(destPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode().Perm())
 	if err != nil {
 		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to create destination file", err)
 	}
@@ -96,10 +140,105 @@ func (e *Extractor) extractFile(file *zip.File, extractedSize *int64, totalSize
 	return nil
 }
 
-// FindTemplateAsset finds the appropriate template asset for the given AI assistant
-func FindTemplateAsset(release *Release, aiAssistant string) (*ReleaseAsset, error) {
+// destPath returns where an archive entry is extracted, or an error when
+// the entry is absolute or its cleaned path escapes the destination directory
+func (e *Extractor) destPath(name string) (string, error) {
+	if filepath.IsAbs(name) || filepath.VolumeName(name) != "" || strings.HasPrefix(name, "/") {
+		return "", errors.NewFileSystemError(
+			fmt.Sprintf("refusing to extract %s: absolute paths are not allowed in archives", name), nil)
+	}
+
+	destPath := filepath.Join(e.destDir, name)
+	if !within(e.destDir, destPath) {
+		return "", errors.NewFileSystemError(
+			fmt.Sprintf("refusing to extract %s: it would be written outside %s", name, e.destDir), nil)
+	}
+
+	// A path through an extracted symlink is only contained on paper
+	parts := strings.Split(e.relPath(destPath), "/")
+	for i := range parts {
+		if prefix := strings.Join(parts[:i+1], "/"); e.symlinks[prefix] {
+			return "", errors.NewFileSystemError(
+				fmt.Sprintf("refusing to extract %s: it would be written through the symlink %s", name, prefix), nil)
+		}
+	}
+	return destPath, nil
+}
+
+// relPath returns path relative to the destination directory, with forward slashes
+func (e *Extractor) relPath(path string) string {
+	rel, err := filepath.Rel(filepath.Clean(e.destDir), filepath.Clean(path))
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// extractSymlink creates the symlink stored in an archive entry, whose
+// content is the link target, if symlinks are allowed and the target
+// resolves inside the destination directory without passing through
+// another extracted symlink
+func (e *Extractor) extractSymlink(name, destPath string, src io.Reader) error {
+	if !e.allowSymlinks {
+		return errors.NewFileSystemError(
+			fmt.Sprintf("refusing to extract %s: archive contains a symlink", name), nil)
+	}
+
+	target, err := io.ReadAll(src)
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to read symlink target", err)
+	}
+	linkTarget := string(target)
+	outside := errors.NewFileSystemError(
+		fmt.Sprintf("refusing to extract %s: symlink target %s is outside %s", name, linkTarget, e.destDir), nil)
+
+	// Walk the target one element at a time, as the filesystem would, so
+	// ".." after an earlier symlink cannot climb out of destDir
+	linkRel := e.relPath(destPath)
+	var resolved []string
+	relTarget := filepath.ToSlash(linkTarget)
+	if filepath.IsAbs(linkTarget) {
+		if !within(e.destDir, linkTarget) {
+			return outside
+		}
+		relTarget = e.relPath(linkTarget)
+	} else if dir := path.Dir(linkRel); dir != "." {
+		resolved = strings.Split(dir, "/")
+	}
+	for _, element := range strings.Split(relTarget, "/") {
+		switch element {
+		case "", ".":
+		case "..":
+			if len(resolved) == 0 {
+				return outside
+			}
+			resolved = resolved[:len(resolved)-1]
+		default:
+			resolved = append(resolved, element)
+			if through := strings.Join(resolved, "/"); e.symlinks[through] {
+				return errors.NewFileSystemError(
+					fmt.Sprintf("refusing to extract %s: symlink target %s goes through the symlink %s", name, linkTarget, through), nil)
+			}
+		}
+	}
+
+	if err := os.Symlink(linkTarget, destPath); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to create symlink", err)
+	}
+	e.symlinks[linkRel] = true
+	return nil
+}
+
+// within reports whether path is dir or lies below it once both are cleaned
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// FindTemplateAsset finds the template asset for the given AI assistant and script type
+func FindTemplateAsset(release *Release, aiAssistant, scriptType string) (*ReleaseAsset, error) {
 	// Look for asset matching the pattern: spec-kit-template-{aiAssistant}-{scriptType}-{version}.zip
-	pattern := fmt.Sprintf("spec-kit-template-%s-", aiAssistant)
+	pattern := fmt.Sprintf("spec-kit-template-%s-%s-", aiAssistant, scriptType)
 
 	for _, asset := range release.Assets {
 		if strings.Contains(asset.Name, pattern) && strings.HasSuffix(asset.Name, ".zip") {
@@ -107,7 +246,7 @@ func FindTemplateAsset(release *Release, aiAssistant string) (*ReleaseAsset, err
 		}
 	}
 
-	return nil, errors.NewAssetNotFound(fmt.Sprintf("template asset for %s", aiAssistant))
+	return nil, errors.NewAssetNotFound(fmt.Sprintf("template asset for %s (%s)", aiAssistant, scriptType))
 }
 
 // Cleanup removes temporary files
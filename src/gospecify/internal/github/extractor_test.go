@@ -0,0 +1,155 @@
+package github
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// zipEntry is a file or, when symlink is set, a symlink to content
+type zipEntry struct {
+	name    string
+	content string
+	symlink bool
+}
+
+// writeZip creates an archive holding entries and returns its path
+func writeZip(t *testing.T, entries ...zipEntry) string {
+	t.Helper()
+	zipPath := filepath.Join(t.TempDir(), "template.zip")
+	file, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer := zip.NewWriter(file)
+	for _, entry := range entries {
+		header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate}
+		header.SetMode(0644)
+		if entry.symlink {
+			header.SetMode(os.ModeSymlink | 0777)
+		}
+		w, err := writer.CreateHeader(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(entry.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return zipPath
+}
+
+// symlinkedDir returns an empty directory reached through a symlink, as
+// when a project directory is itself a symlink
+func symlinkedDir(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symlinks needs extra privileges on Windows")
+	}
+	base := t.TempDir()
+	real := filepath.Join(base, "real")
+	if err := os.Mkdir(real, 0755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(base, "link")
+	if err := os.Symlink(real, link); err != nil {
+		t.Fatal(err)
+	}
+	return link
+}
+
+func TestExtractZipRejectsEscapingSymlink(t *testing.T) {
+	outside := t.TempDir()
+	for _, allow := range []bool{false, true} {
+		t.Run(map[bool]string{false: "symlinks refused", true: "symlinks allowed"}[allow], func(t *testing.T) {
+			destDir := symlinkedDir(t)
+			zipPath := writeZip(t,
+				zipEntry{name: "templates/plan.md", content: "# Plan"},
+				zipEntry{name: "templates/escape", content: outside, symlink: true},
+				zipEntry{name: "templates/escape/owned.md", content: "owned"},
+			)
+
+			err := NewExtractor(destDir).WithSymlinks(allow).ExtractZip(zipPath, nil)
+			if errors.CodeOf(err) != errors.ErrCodeFileSystemError {
+				t.Fatalf("ExtractZip() error = %v, want a file system error", err)
+			}
+			if !strings.Contains(err.Error(), "templates/escape") {
+				t.Errorf("error %q does not name the symlink entry", err)
+			}
+			if _, err := os.Lstat(filepath.Join(destDir, "templates", "escape")); !os.IsNotExist(err) {
+				t.Errorf("symlink was created (lstat error = %v)", err)
+			}
+			if _, err := os.Stat(filepath.Join(outside, "owned.md")); !os.IsNotExist(err) {
+				t.Errorf("a file was written outside the destination (stat error = %v)", err)
+			}
+		})
+	}
+}
+
+func TestExtractZipRejectsChainedSymlinks(t *testing.T) {
+	for name, entries := range map[string][]zipEntry{
+		"entry through a symlink": {
+			{name: "x", content: ".", symlink: true},
+			{name: "x/y", content: "..", symlink: true},
+			{name: "y/evil.md", content: "owned"},
+		},
+		"target through a symlink": {
+			{name: "sub/x", content: "..", symlink: true},
+			{name: "sub/y", content: "x/..", symlink: true},
+			{name: "sub/y/evil.md", content: "owned"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			destDir := symlinkedDir(t)
+			err := NewExtractor(destDir).WithSymlinks(true).ExtractZip(writeZip(t, entries...), nil)
+			if errors.CodeOf(err) != errors.ErrCodeFileSystemError {
+				t.Fatalf("ExtractZip() error = %v, want a file system error", err)
+			}
+			parent := filepath.Dir(destDir)
+			for _, evil := range []string{filepath.Join(parent, "evil.md"), filepath.Join(parent, "y", "evil.md")} {
+				if _, err := os.Stat(evil); !os.IsNotExist(err) {
+					t.Errorf("%s was written outside the destination (stat error = %v)", evil, err)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractZipAllowsContainedSymlink(t *testing.T) {
+	destDir := symlinkedDir(t)
+	zipPath := writeZip(t,
+		zipEntry{name: "templates/plan.md", content: "# Plan"},
+		zipEntry{name: "templates/current.md", content: "plan.md", symlink: true},
+	)
+
+	if err := NewExtractor(destDir).WithSymlinks(true).ExtractZip(zipPath, nil); err != nil {
+		t.Fatalf("ExtractZip() error = %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(destDir, "templates", "current.md"))
+	if err != nil || string(content) != "# Plan" {
+		t.Errorf("current.md = %q, %v; want the linked plan.md", content, err)
+	}
+}
+
+func TestExtractZipRejectsParentPaths(t *testing.T) {
+	destDir := symlinkedDir(t)
+	zipPath := writeZip(t, zipEntry{name: "../outside.md", content: "owned"})
+
+	if err := NewExtractor(destDir).ExtractZip(zipPath, nil); errors.CodeOf(err) != errors.ErrCodeFileSystemError {
+		t.Fatalf("ExtractZip() error = %v, want a file system error", err)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(destDir), "outside.md")); !os.IsNotExist(err) {
+		t.Errorf("a file was written outside the destination (stat error = %v)", err)
+	}
+}
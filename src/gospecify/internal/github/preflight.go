@@ -0,0 +1,81 @@
+// Package github provides GitHub API integration
+package github
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// PreflightTimeout bounds the reachability check made before downloading
+const PreflightTimeout = 5 * time.Second
+
+// Preflight checks that the GitHub API is reachable, so that downloads fail
+// early with an actionable diagnosis instead of midway through a transfer.
+// The returned error distinguishes DNS and proxy problems, a missing network
+// connection, and GitHub itself being unavailable.
+func (c *Client) Preflight(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, PreflightTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeNetworkError, "failed to create request", err)
+	}
+	req.Header.Set("User-Agent", config.UserAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return diagnoseConnectError(c.baseURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return errors.NewNetworkError(
+			fmt.Sprintf("GitHub appears to be down: %s returned %d (see https://www.githubstatus.com)", c.baseURL, resp.StatusCode), nil)
+	}
+
+	// Any other response, including 401/403, proves the API is reachable
+	return nil
+}
+
+// diagnoseConnectError turns a transport error into a message naming the likely cause
+func diagnoseConnectError(baseURL string, err error) error {
+	host := baseURL
+	if parsed, parseErr := url.Parse(baseURL); parseErr == nil && parsed.Host != "" {
+		host = parsed.Host
+	}
+
+	var urlErr *url.Error
+	if stderrors.As(err, &urlErr) && urlErr.Op == "proxyconnect" {
+		return errors.NewNetworkError(
+			fmt.Sprintf("could not connect through the configured proxy to reach %s; check HTTPS_PROXY", host), err)
+	}
+
+	var opErr *net.OpError
+	if stderrors.As(err, &opErr) && opErr.Op == "proxyconnect" {
+		return errors.NewNetworkError(
+			fmt.Sprintf("could not connect through the configured proxy to reach %s; check HTTPS_PROXY", host), err)
+	}
+
+	var dnsErr *net.DNSError
+	if stderrors.As(err, &dnsErr) {
+		return errors.NewNetworkError(
+			fmt.Sprintf("could not resolve %s; check your DNS settings or proxy configuration", host), err)
+	}
+
+	if stderrors.Is(err, context.DeadlineExceeded) {
+		return errors.NewNetworkError(
+			fmt.Sprintf("timed out after %s connecting to %s; you may be offline or behind a firewall", PreflightTimeout, host), err)
+	}
+
+	return errors.NewNetworkError(
+		fmt.Sprintf("could not connect to %s; check your network connection", host), err)
+}
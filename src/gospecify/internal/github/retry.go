@@ -0,0 +1,116 @@
+// Package github provides GitHub API integration
+package github
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// Retry defaults for GitHub requests
+const (
+	DefaultRetries = 2 // three attempts in total
+	DefaultBackoff = 500 * time.Millisecond
+
+	// maxRetryAfter caps how long a Retry-After header can make us wait
+	maxRetryAfter = time.Minute
+)
+
+// ClientOption configures a Client
+type ClientOption func(*Client)
+
+// WithRetries sets how many times a failed request is retried after the first attempt
+func WithRetries(n int) ClientOption {
+	return func(c *Client) {
+		if n < 0 {
+			n = 0
+		}
+		c.retries = n
+	}
+}
+
+// WithLogger logs every request and response at debug level, and retries at info level
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// WithBackoff sets the delay before the first retry; each later retry doubles it
+func WithBackoff(base time.Duration) ClientOption {
+	return func(c *Client) {
+		c.backoff = base
+	}
+}
+
+// do sends req, retrying network errors and 5xx/429 responses with
+// exponential backoff. A Retry-After header overrides the computed delay.
+// Other 4xx responses are returned as is.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		c.logger.Debug("GitHub request", "method", req.Method, "url", req.URL.Redacted())
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			c.logger.Debug("GitHub request failed", "url", req.URL.Redacted(), "error", err)
+		} else {
+			c.logger.Debug("GitHub response", "url", req.URL.Redacted(), "status", resp.StatusCode)
+		}
+		if !shouldRetry(resp, err) || attempt >= c.retries || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		delay := c.backoff << attempt
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				delay = retryAfter
+			}
+			_ = resp.Body.Close()
+		}
+
+		c.logger.Info("retrying GitHub request", "url", req.URL.Redacted(), "attempt", attempt+2, "delay", delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, errors.NewCancelled("GitHub request cancelled while waiting to retry")
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry reports whether a request outcome is likely transient
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = time.Until(date)
+	} else {
+		return 0, false
+	}
+
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > maxRetryAfter {
+		delay = maxRetryAfter
+	}
+	return delay, true
+}
@@ -0,0 +1,173 @@
+// Package manifest records the files gospecify generates in a project
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// Version is the current manifest schema version
+const Version = 1
+
+// RelativePath is the project-relative location of the manifest
+const RelativePath = ".specify/manifest.json"
+
+// Manifest lists the files generated by gospecify along with their hashes
+type Manifest struct {
+	Version     int      `json:"version"`
+	GeneratedBy string   `json:"generated_by"`
+	AIAssistant string   `json:"ai_assistant"`
+	ScriptType  string   `json:"script_type"`
+	TemplateSet string   `json:"template_set,omitempty"`
+	Commands    []string `json:"commands,omitempty"`
+	// KeepTemplateStructure is set when scripts keep the template archive
+	// layout (.specify/scripts/bash/...) instead of being flattened
+	KeepTemplateStructure bool `json:"keep_template_structure,omitempty"`
+	// Replacements are the --owner and --set placeholder values the files
+	// were generated with, so they can be regenerated identically
+	Replacements map[string]string `json:"replacements,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+	Files        []File            `json:"files"`
+}
+
+// File is a single managed file
+type File struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// New creates an empty manifest for the given configuration
+func New(cfg *config.ProjectConfig) *Manifest {
+	return &Manifest{
+		Version:               Version,
+		GeneratedBy:           config.UserAgent,
+		AIAssistant:           cfg.AIAssistant,
+		ScriptType:            cfg.ScriptType,
+		TemplateSet:           cfg.TemplateSet,
+		Commands:              cfg.Commands,
+		KeepTemplateStructure: cfg.KeepTemplateStructure,
+		CreatedAt:             cfg.CreatedAt,
+	}
+}
+
+// Hash returns the hex-encoded SHA-256 of content
+func Hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Add records a file, replacing any previous entry for the same path
+func (m *Manifest) Add(relPath string, content []byte) {
+	relPath = filepath.ToSlash(relPath)
+	entry := File{Path: relPath, SHA256: Hash(content)}
+
+	for i := range m.Files {
+		if m.Files[i].Path == relPath {
+			m.Files[i] = entry
+			return
+		}
+	}
+	m.Files = append(m.Files, entry)
+}
+
+// Lookup returns the entry for a path, if it is managed
+func (m *Manifest) Lookup(relPath string) (File, bool) {
+	relPath = filepath.ToSlash(relPath)
+	for _, file := range m.Files {
+		if file.Path == relPath {
+			return file, true
+		}
+	}
+	return File{}, false
+}
+
+// Paths returns the sorted managed paths
+func (m *Manifest) Paths() []string {
+	paths := make([]string, 0, len(m.Files))
+	for _, file := range m.Files {
+		paths = append(paths, file.Path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Exists reports whether projectPath contains a manifest
+func Exists(projectPath string) bool {
+	_, err := os.Stat(filepath.Join(projectPath, filepath.FromSlash(RelativePath)))
+	return err == nil
+}
+
+// Load reads the manifest from projectPath
+func Load(projectPath string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(projectPath, filepath.FromSlash(RelativePath)))
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeFileSystemError, "failed to read manifest", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInvalidConfig, "failed to parse manifest", err)
+	}
+	if m.Version > Version {
+		return nil, errors.NewInvalidConfig(
+			fmt.Sprintf("manifest version %d is newer than supported version %d; upgrade gospecify", m.Version, Version))
+	}
+
+	for _, file := range m.Files {
+		if err := ValidatePath(file.Path); err != nil {
+			return nil, err
+		}
+	}
+
+	return &m, nil
+}
+
+// Marshal encodes the manifest with its files sorted by path
+func (m *Manifest) Marshal() ([]byte, error) {
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].Path < m.Files[j].Path })
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeFileSystemError, "failed to encode manifest", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// Save writes the manifest into projectPath
+func (m *Manifest) Save(projectPath string) error {
+	data, err := m.Marshal()
+	if err != nil {
+		return err
+	}
+
+	manifestPath := filepath.Join(projectPath, filepath.FromSlash(RelativePath))
+	if err := os.MkdirAll(filepath.Dir(manifestPath), 0755); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to create manifest directory", err)
+	}
+	if err := os.WriteFile(manifestPath, data, 0644); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to write manifest", err)
+	}
+
+	return nil
+}
+
+// ValidatePath rejects manifest paths that would escape the project directory
+func ValidatePath(relPath string) error {
+	cleaned := path.Clean(relPath)
+	if relPath == "" || path.IsAbs(relPath) || filepath.IsAbs(relPath) ||
+		cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return errors.NewInvalidConfig(fmt.Sprintf("manifest contains unsafe path %q", relPath))
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Package scaffold implements the project initialization shared by the CLI and pkg/initializer
+package scaffold
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/github"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/ui"
+)
+
+// newGitHubClient creates a GitHub client honoring --skip-tls, --proxy,
+// --github-api-url, --mirror, --no-cache and --cache-ttl
+func newGitHubClient(token string, cfg *config.ProjectConfig) *github.Client {
+	opts := []github.ClientOption{github.WithLogger(logger(cfg))}
+	// validateConfig already rejected unparsable URLs
+	if cfg.Proxy != "" {
+		if proxyURL, err := url.Parse(cfg.Proxy); err == nil {
+			opts = append(opts, github.WithProxy(proxyURL))
+		}
+	}
+	if cfg.GitHubAPIURL != "" {
+		opts = append(opts, github.WithBaseURL(cfg.GitHubAPIURL))
+	}
+	if cfg.Mirror != "" {
+		if mirrorURL, err := url.Parse(cfg.Mirror); err == nil {
+			opts = append(opts, github.WithMirror(mirrorURL))
+		}
+	}
+	if dir := github.DefaultCacheDir(); dir != "" {
+		opts = append(opts, github.WithReleaseCache(dir, cfg.CacheTTL, cfg.NoCache))
+	}
+	return github.NewClient(token, cfg.SkipTLS, opts...)
+}
+
+// checkTokenScopes inspects the scopes of the configured GitHub token, reporting
+// them under --debug and warning when a private repository cannot be read with them
+func checkTokenScopes(ctx context.Context, cfg *config.ProjectConfig, renderer ui.OutputRenderer, requirePrivate bool) {
+	token := github.GetGitHubToken(cfg.GitHubToken, cfg.GitHubAPIURL)
+	if token == "" {
+		return
+	}
+
+	client := newGitHubClient(token, cfg)
+	if err := client.Preflight(ctx); err != nil {
+		renderer.Warn(fmt.Sprintf("skipping GitHub token check: %v", err))
+		return
+	}
+
+	scopes, classic, err := client.GetTokenScopes(ctx)
+	if err != nil {
+		renderer.Warn(fmt.Sprintf("could not verify GitHub token: %v", err))
+		return
+	}
+
+	if !classic {
+		debugf(cfg, "GitHub token scopes: not reported (fine-grained or app token)")
+		return
+	}
+	debugf(cfg, "GitHub token scopes: %s", strings.Join(scopes, ", "))
+
+	if requirePrivate && !github.HasScope(scopes, config.GitHubPrivateRepoScope) {
+		renderer.Warn(fmt.Sprintf(
+			"GitHub token lacks the '%s' scope; requests for private repositories will fail with 404",
+			config.GitHubPrivateRepoScope))
+	}
+}
@@ -0,0 +1,86 @@
+// Package scaffold implements the project initialization shared by the CLI and pkg/initializer
+package scaffold
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// gitignoreFolders returns the agent folders of assistants that may hold
+// credentials. Copilot's folder is .github/, which holds workflows and other
+// repository files, so it is never ignored.
+func gitignoreFolders(assistants []*config.AIAssistant) []string {
+	var folders []string
+	for _, assistant := range assistants {
+		folder, exists := config.AgentFolderMap[assistant.Key]
+		if !exists || strings.Trim(folder, "/") == ".github" {
+			continue
+		}
+		folders = append(folders, folder)
+	}
+	return folders
+}
+
+// updateGitignore appends each folder missing from the project's .gitignore,
+// creating the file if needed, and returns the folders added and those that
+// were already listed. Nothing is written in a dry run.
+func updateGitignore(projectPath string, folders []string, dryRun bool, journal *rollbackJournal) ([]string, []string, error) {
+	gitignorePath := filepath.Join(projectPath, ".gitignore")
+	existing, err := os.ReadFile(gitignorePath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, errors.Wrap(errors.ErrCodeFileSystemError, "failed to read .gitignore", err)
+	}
+
+	listed := make(map[string]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(existing))
+	for scanner.Scan() {
+		// .claude, .claude/, /.claude and /.claude/ all ignore the folder
+		entry := strings.Trim(strings.TrimSpace(scanner.Text()), "/")
+		if entry != "" && !strings.HasPrefix(entry, "#") {
+			listed[entry] = true
+		}
+	}
+
+	var added, present []string
+	for _, folder := range folders {
+		entry := strings.Trim(folder, "/")
+		if listed[entry] {
+			present = append(present, folder)
+			continue
+		}
+		listed[entry] = true
+		added = append(added, folder)
+	}
+	if len(added) == 0 || dryRun {
+		return added, present, nil
+	}
+
+	var content strings.Builder
+	if len(existing) > 0 && !bytes.HasSuffix(existing, []byte("\n")) {
+		content.WriteString("\n")
+	}
+	content.WriteString("# AI agent folders, which may hold credentials\n")
+	for _, folder := range added {
+		content.WriteString(folder + "\n")
+	}
+
+	journal.track(".gitignore")
+	file, err := os.OpenFile(gitignorePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, nil, errors.Wrap(errors.ErrCodeFileSystemError, "failed to open .gitignore", err)
+	}
+	if _, err := file.WriteString(content.String()); err != nil {
+		_ = file.Close()
+		return nil, nil, errors.Wrap(errors.ErrCodeFileSystemError, "failed to write .gitignore", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, nil, errors.Wrap(errors.ErrCodeFileSystemError, "failed to write .gitignore", err)
+	}
+	return added, present, nil
+}
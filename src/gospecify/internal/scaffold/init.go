@@ -0,0 +1,1801 @@
+// Package scaffold implements the project initialization shared by the CLI and pkg/initializer
+package scaffold
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/mail"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/diskspace"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/manifest"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/scripts"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/templates"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/ui"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// contextError describes why ctx ended: the --timeout cause, or an interrupt
+func contextError(ctx context.Context) error {
+	if cause := context.Cause(ctx); errors.CodeOf(cause) != "" {
+		return cause
+	}
+	return errors.NewCancelled("init interrupted")
+}
+
+// startStep starts a tracker step, or marks it failed and returns the
+// reason when ctx has already ended
+func startStep(ctx context.Context, tracker *config.StepTracker, key string) error {
+	if ctx.Err() != nil {
+		err := contextError(ctx)
+		tracker.Error(key, err.Error())
+		return err
+	}
+	tracker.Start(key, "")
+	return nil
+}
+
+// Run scaffolds the project described by cfg, reporting progress through the renderer
+func Run(ctx context.Context, cfg *config.ProjectConfig, renderer ui.OutputRenderer) (result *config.InitResult, err error) {
+	// Initialize progress tracker
+	tracker := &config.StepTracker{
+		Title: "Initializing Specify Project",
+	}
+	tracker.Add("validate", "Validate configuration")
+	tracker.Add("assistant", "Select AI assistant")
+	tracker.Add("script", "Select script type")
+	tracker.Add("tools", "Check required tools")
+	tracker.Add("download", "Prepare project directory")
+	tracker.Add("extract", "Setup template assets")
+	tracker.Add("process", "Process templates")
+	tracker.Add("scripts", "Generate scripts")
+	if cfg.WithEditorConfig {
+		tracker.Add("editor", "Write editor configuration")
+	}
+	if cfg.Describe != "" {
+		tracker.Add("spec", "Seed initial specification")
+	}
+	if cfg.Verify {
+		tracker.Add("verify", "Verify written files")
+	}
+	if cfg.Gitignore {
+		tracker.Add("gitignore", "Ignore agent folders in .gitignore")
+	}
+	tracker.Add("git", "Initialize git repository")
+
+	// Route step changes to the renderer, and to the log at info level
+	tracker.AttachListener(renderer.StepUpdate)
+	// Library callers may route logs elsewhere
+	if cfg.Logger == nil {
+		cfg.Logger = newLogger(cfg)
+	}
+	tracker.AttachListener(logStep(cfg.Logger))
+	renderer.Begin(tracker)
+
+	// Step 1: Validate configuration
+	if err := startStep(ctx, tracker, "validate"); err != nil {
+		return nil, err
+	}
+	if err := validateConfig(cfg); err != nil {
+		tracker.Error("validate", err.Error())
+		return nil, err
+	}
+	if cfg.RetryStep != "" {
+		if err := prepareRetry(cfg, tracker); err != nil {
+			tracker.Error("validate", err.Error())
+			return nil, err
+		}
+	}
+	for _, key := range reservedValues(cfg) {
+		renderer.Warn(fmt.Sprintf("--set %s is ignored: %s is filled in by gospecify itself", key, setPlaceholder(key)))
+	}
+	tracker.Complete("validate", "Configuration valid")
+
+	// Step 2: Select AI assistant
+	if err := startStep(ctx, tracker, "assistant"); err != nil {
+		return nil, err
+	}
+	assistants, err := selectAssistants(cfg, renderer)
+	if err != nil {
+		tracker.Error("assistant", err.Error())
+		return nil, err
+	}
+	// The first assistant drives the script type and the .specify templates
+	assistant := assistants[0]
+	if len(assistants) > 1 {
+		tracker.Complete("assistant", fmt.Sprintf("Selected all %d assistants", len(assistants)))
+	} else {
+		cfg.AIAssistant = assistant.Key
+		tracker.Complete("assistant", fmt.Sprintf("Selected %s", assistant.Name))
+	}
+
+	// Step 3: Select script type
+	if err := startStep(ctx, tracker, "script"); err != nil {
+		return nil, err
+	}
+	scriptType, err := selectScriptType(cfg, assistant, renderer)
+	if err != nil {
+		tracker.Error("script", err.Error())
+		return nil, err
+	}
+	cfg.ScriptType = scriptType
+	tracker.Complete("script", fmt.Sprintf("Selected %s", config.ScriptTypes[scriptType].Name))
+
+	// Step 4: Check required tools
+	if err := startStep(ctx, tracker, "tools"); err != nil {
+		return nil, err
+	}
+	if err := checkRequiredTools(assistants, cfg.IgnoreTools, renderer); err != nil {
+		tracker.Error("tools", err.Error())
+		return nil, err
+	}
+	tracker.Complete("tools", "All tools available")
+
+	// Only reach out to GitHub for token diagnostics when asked to, or when
+	// a --template-repo, which may be private, is about to be downloaded
+	if cfg.Debug || cfg.TemplateRepo != "" {
+		checkTokenScopes(ctx, cfg, renderer, cfg.TemplateRepo != "")
+	}
+
+	// Step 5: Prepare project directory
+	downloadDetail := ""
+	createdProject := false
+	if resumedStep(cfg, tracker, "download") {
+		tracker.Skip("download", "Completed previously")
+	} else {
+		if err := startStep(ctx, tracker, "download"); err != nil {
+			return nil, err
+		}
+		projectPath, err := prepareProjectDirectory(cfg)
+		if err != nil {
+			tracker.Error("download", err.Error())
+			return nil, err
+		}
+		cfg.Path = projectPath
+		createdProject = !cfg.Here && !cfg.DryRun
+		if cfg.DryRun && !cfg.Here {
+			downloadDetail = "Would create " + projectPath
+		} else {
+			downloadDetail = "Project directory prepared"
+		}
+		tracker.Complete("download", downloadDetail)
+	}
+	projectPath := cfg.Path
+
+	// From here on the project directory exists, so on failure either undo
+	// this run's changes or persist progress for --retry-step. Files that
+	// fail --verify cannot be trusted, so that failure always rolls back.
+	var journal *rollbackJournal
+	if (cfg.RollbackOnError || cfg.Verify) && !cfg.DryRun {
+		journal = newRollbackJournal(projectPath)
+	}
+	verifyFailed := false
+	defer func() {
+		if err != nil && (cfg.RollbackOnError || verifyFailed) && !cfg.DryRun {
+			rollBackInit(projectPath, createdProject, journal, renderer)
+			return
+		}
+		if err != nil && !cfg.DryRun {
+			if saveErr := saveInitState(cfg, tracker); saveErr == nil {
+				renderer.Info(fmt.Sprintf("Progress saved to %s; fix the problem and re-run with --retry-step", initStatePath))
+			}
+		}
+	}()
+
+	// Step 6: Load the template assets and make sure they fit
+	if err := startStep(ctx, tracker, "extract"); err != nil {
+		return nil, err
+	}
+	source := assetSourceFor(ctx, cfg)
+	if release, ok := source.(releaseSource); ok {
+		// Only the decorated output has a line to redraw
+		if human, ok := renderer.(*ui.HumanRenderer); ok {
+			release.progress = human.DownloadProgress("Downloading " + release.Describe())
+			source = release
+		}
+	}
+	assets, err := LoadAssets(source)
+	if err != nil && ctx.Err() != nil {
+		// An interrupted download must not fall back to the embedded templates
+		err = contextError(ctx)
+	}
+	switch {
+	case err != nil && cfg.TemplateRepo != "" && errors.CodeOf(err) == errors.ErrCodeAssetNotFound:
+		renderer.Warn(fmt.Sprintf("%s has no template archive for %s; using the embedded templates instead",
+			source.Describe(), cfg.AIAssistant))
+		source = templates.EmbeddedSource{SetName: cfg.TemplateSet}
+		assets, err = LoadAssets(source)
+	case err != nil && cfg.UseRelease && releaseUnavailable(err):
+		renderer.Warn(fmt.Sprintf("%v; using the embedded templates instead", err))
+		source = templates.EmbeddedSource{SetName: cfg.TemplateSet}
+		assets, err = LoadAssets(source)
+		if downloadDetail != "" {
+			tracker.Complete("download", downloadDetail+", release unavailable")
+		}
+	case err == nil && cfg.UseRelease && downloadDetail != "":
+		tracker.Complete("download", downloadDetail+", downloaded "+source.Describe())
+	}
+	if err != nil {
+		tracker.Error("extract", err.Error())
+		return nil, err
+	}
+	if err := checkDiskSpace(cfg, assets, assistants); err != nil {
+		tracker.Error("extract", err.Error())
+		return nil, err
+	}
+	tracker.Complete("extract", "Using "+source.Describe())
+
+	// Remove previously managed files before re-scaffolding
+	if cfg.CleanBefore && !resumedStep(cfg, tracker, "process") {
+		if err := cleanManagedFiles(cfg, renderer); err != nil {
+			return nil, err
+		}
+	}
+
+	// Another assistant's .specify/ is shared rather than overwritten
+	var coexisting []string
+	if cfg.Here && !cfg.CommandsOnly && !cfg.CleanBefore {
+		coexisting = priorAssistants(projectPath, assistants)
+	}
+	if len(coexisting) > 0 {
+		renderer.Info(fmt.Sprintf("This project is already set up for %s: keeping its .specify/ templates and scripts and adding %s",
+			strings.Join(coexisting, ", "), strings.Join(assistantDirectories(assistants), ", ")))
+	}
+
+	// Record every generated file in the project manifest, extending the
+	// existing one when resuming past the steps that populated it, when
+	// only the commands are being reinstalled, or when adding an assistant
+	projectManifest := manifest.New(cfg)
+	projectManifest.Replacements = ProjectReplacements(cfg)
+	if (cfg.RetryStep != "" || cfg.CommandsOnly || len(coexisting) > 0) && manifest.Exists(projectPath) {
+		if projectManifest, err = manifest.Load(projectPath); err != nil {
+			return nil, err
+		}
+		if cfg.CommandsOnly {
+			projectManifest.Commands = cfg.Commands
+			projectManifest.Replacements = ProjectReplacements(cfg)
+		}
+	}
+	writer := NewProjectWriter(projectPath, projectManifest)
+	writer.logger = cfg.Logger
+	writer.dryRun = cfg.DryRun
+	writer.journal = journal
+	writer.keepShared = len(coexisting) > 0
+
+	// Step 7: Process templates
+	if resumedStep(cfg, tracker, "process") {
+		tracker.Skip("process", "Completed previously")
+	} else {
+		if err := startStep(ctx, tracker, "process"); err != nil {
+			return nil, err
+		}
+		if err := processTemplates(cfg, assets, assistants, writer, renderer, func(current, total int) {
+			tracker.Progress("process", current, total)
+		}); err != nil {
+			tracker.Error("process", err.Error())
+			return nil, err
+		}
+		if cfg.CommandsOnly {
+			if err := writer.saveManifest(); err != nil {
+				tracker.Error("process", err.Error())
+				return nil, err
+			}
+			completeStep(tracker, renderer, writer, "process", "Commands installed into "+strings.Join(assistantDirectories(assistants), ", "))
+		} else {
+			completeStep(tracker, renderer, writer, "process", "Templates processed")
+		}
+	}
+
+	// Step 8: Generate scripts
+	if cfg.CommandsOnly {
+		tracker.Skip("scripts", "Skipped (--commands-only)")
+	} else if resumedStep(cfg, tracker, "scripts") {
+		tracker.Skip("scripts", "Completed previously")
+	} else {
+		if err := startStep(ctx, tracker, "scripts"); err != nil {
+			return nil, err
+		}
+		if err := generateScripts(cfg, assets, assistants, writer, renderer, func(current, total int) {
+			tracker.Progress("scripts", current, total)
+		}); err != nil {
+			tracker.Error("scripts", err.Error())
+			return nil, err
+		}
+		if err := writer.saveManifest(); err != nil {
+			tracker.Error("scripts", err.Error())
+			return nil, err
+		}
+		completeStep(tracker, renderer, writer, "scripts", "Scripts generated")
+	}
+
+	if kept := writer.takeKept(); len(kept) > 0 {
+		renderer.Warn(fmt.Sprintf("kept %d shared files with local changes instead of overwriting them:\n  %s",
+			len(kept), strings.Join(kept, "\n  ")))
+	}
+
+	// Recommend the GitHub Copilot extensions in VS Code
+	if cfg.WithEditorConfig {
+		if resumedStep(cfg, tracker, "editor") {
+			tracker.Skip("editor", "Completed previously")
+		} else {
+			if err := startStep(ctx, tracker, "editor"); err != nil {
+				return nil, err
+			}
+			written := 0
+			for _, a := range assistants {
+				n, err := writeEditorConfig(cfg, a, writer, renderer)
+				if err != nil {
+					tracker.Error("editor", err.Error())
+					return nil, err
+				}
+				written += n
+			}
+			if written == 0 {
+				tracker.Skip("editor", "Editor configuration is only written for GitHub Copilot")
+			} else {
+				completeStep(tracker, renderer, writer, "editor", fmt.Sprintf("%d files written", written))
+			}
+		}
+	}
+
+	// Seed the first spec so users can run /plan straight away
+	var seededSpec string
+	if cfg.Describe != "" {
+		if resumedStep(cfg, tracker, "spec") {
+			tracker.Skip("spec", "Completed previously")
+		} else {
+			if err := startStep(ctx, tracker, "spec"); err != nil {
+				return nil, err
+			}
+			specPath, err := seedInitialSpec(cfg, assets, journal)
+			if err != nil {
+				tracker.Error("spec", err.Error())
+				return nil, err
+			}
+			seededSpec = specPath
+			if cfg.DryRun {
+				tracker.Complete("spec", "Would write "+specPath)
+			} else {
+				tracker.Complete("spec", specPath)
+			}
+		}
+	}
+
+	// Re-read everything written so partial writes fail here rather than later
+	if cfg.Verify && cfg.DryRun {
+		tracker.Skip("verify", "Skipped (dry run)")
+	} else if cfg.Verify {
+		if err := startStep(ctx, tracker, "verify"); err != nil {
+			return nil, err
+		}
+		if problems := writer.verify(); len(problems) > 0 {
+			err := errors.NewFileSystemError(fmt.Sprintf(
+				"%d written files failed verification:\n  %s", len(problems), strings.Join(problems, "\n  ")), nil)
+			tracker.Error("verify", fmt.Sprintf("%d files failed verification", len(problems)))
+			verifyFailed = true
+			return nil, err
+		}
+		tracker.Complete("verify", fmt.Sprintf("%d files verified", len(writer.manifest.Files)))
+	}
+
+	// The initial commit stages everything, so the entries go in before it
+	var gitignored []string
+	if cfg.Gitignore {
+		if err := startStep(ctx, tracker, "gitignore"); err != nil {
+			return nil, err
+		}
+		added, present, err := updateGitignore(projectPath, gitignoreFolders(assistants), cfg.DryRun, journal)
+		if err != nil {
+			tracker.Error("gitignore", err.Error())
+			return nil, err
+		}
+		gitignored = append(added, present...)
+		var details []string
+		if len(added) > 0 {
+			verb := "Added"
+			if cfg.DryRun {
+				verb = "Would add"
+			}
+			details = append(details, verb+" "+strings.Join(added, ", "))
+		}
+		if len(present) > 0 {
+			details = append(details, "already present: "+strings.Join(present, ", "))
+		}
+		switch {
+		case len(gitignored) == 0:
+			tracker.Skip("gitignore", "No agent folders to ignore")
+		case len(added) == 0:
+			tracker.Skip("gitignore", "Already present: "+strings.Join(present, ", "))
+		default:
+			tracker.Complete("gitignore", strings.Join(details, "; "))
+		}
+	}
+
+	result = &config.InitResult{
+		Name:          cfg.Name,
+		Path:          cfg.Path,
+		Here:          cfg.Here,
+		AIAssistant:   cfg.AIAssistant,
+		ScriptType:    scriptType,
+		AIAssistants:  assistantKeys(assistants),
+		DryRun:        cfg.DryRun,
+		Commands:      cfg.Commands,
+		SlashCommands: slashCommands(assets, cfg.Commands),
+		Gitignored:    gitignored,
+	}
+	if cfg.ShowTree || cfg.OutputFormat == config.OutputJSON {
+		result.Files = append(writer.manifest.Paths(), manifest.RelativePath)
+		if seededSpec != "" {
+			result.Files = append(result.Files, seededSpec)
+		}
+	}
+
+	// Step 9: Initialize git repository
+	if cfg.DryRun {
+		tracker.Skip("git", planGit(projectPath, cfg.NoGit, cfg.Branch))
+		result.Git = "dry-run"
+		return result, nil
+	}
+	_, statErr := os.Stat(filepath.Join(projectPath, ".git"))
+	existingRepo := statErr == nil
+	if err := startStep(ctx, tracker, "git"); err != nil {
+		return nil, err
+	}
+	if !cfg.NoGit {
+		journal.track(".git")
+	}
+	if err := initializeGit(projectPath, cfg); err != nil {
+		tracker.Error("git", err.Error())
+		return nil, err
+	}
+	switch {
+	case cfg.NoGit:
+		tracker.Skip("git", "Skipped")
+		result.Git = "skipped"
+	case existingRepo && cfg.RetryStep != "git":
+		result.Git = "existing"
+		result.Remote = gitRemoteURL(cfg, projectPath)
+		detail := "Using the existing git repository"
+		if cfg.Commit {
+			generated := append(writer.manifest.Paths(), manifest.RelativePath)
+			if seededSpec != "" {
+				generated = append(generated, seededSpec)
+			}
+			committed, ignored, err := commitGeneratedFiles(cfg, projectPath, generated)
+			if err != nil {
+				tracker.Error("git", err.Error())
+				return nil, err
+			}
+			switch {
+			case committed > 0:
+				detail = fmt.Sprintf("Committed %d generated files to the existing repository", committed)
+				result.Git = "committed"
+			default:
+				detail = "Existing repository already has the generated files"
+			}
+			if len(ignored) > 0 {
+				detail += fmt.Sprintf(" (%d ignored by .gitignore left out)", len(ignored))
+			}
+		}
+		tracker.Complete("git", detail)
+	default:
+		tracker.Complete("git", "Git repository initialized on branch "+cfg.Branch)
+		result.Git = "initialized"
+	}
+
+	return result, nil
+}
+
+// rollBackInit undoes a failed run: a project directory the run created is
+// removed outright, otherwise only the paths recorded in journal are
+func rollBackInit(projectPath string, createdProject bool, journal *rollbackJournal, renderer ui.OutputRenderer) {
+	if createdProject {
+		if err := os.RemoveAll(projectPath); err != nil {
+			renderer.Warn(fmt.Sprintf("rollback could not remove %s: %v", projectPath, err))
+			return
+		}
+		renderer.Info("Rolled back: removed " + projectPath)
+		return
+	}
+
+	removed, err := journal.rollback()
+	if err != nil {
+		renderer.Warn(fmt.Sprintf("rollback removed %d paths but failed on another: %v", removed, err))
+		return
+	}
+	renderer.Info(fmt.Sprintf("Rolled back: removed %d files and directories created by this run", removed))
+}
+
+// completeStep marks a writing step done. On a dry run the detail reports
+// the planned operations instead, which are also listed through the renderer.
+func completeStep(tracker *config.StepTracker, renderer ui.OutputRenderer, writer *ProjectWriter, key, detail string) {
+	if !writer.dryRun {
+		tracker.Complete(key, detail)
+		return
+	}
+
+	planned := writer.takePlanned()
+	tracker.Complete(key, fmt.Sprintf("Would perform %d file operations", len(planned)))
+	if len(planned) > 0 {
+		renderer.Info(fmt.Sprintf("Planned for %s:\n  %s", key, strings.Join(planned, "\n  ")))
+	}
+}
+
+// planGit describes what initializeGit would do in projectPath
+func planGit(projectPath string, noGit bool, branch string) string {
+	if noGit {
+		return "Skipped"
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, ".git")); err == nil {
+		return "Would leave the existing git repository untouched"
+	}
+	return fmt.Sprintf("Would run git init on branch %s, git add . and git commit", branch)
+}
+
+// promptsAllowed reports whether init may ask the user questions; --yes and JSON
+// output is meant for pipelines, where nobody can answer
+func promptsAllowed(cfg *config.ProjectConfig) bool {
+	return cfg.OutputFormat != config.OutputJSON && !cfg.NonInteractive
+}
+
+// validateConfig validates the initial configuration
+func validateConfig(cfg *config.ProjectConfig) error {
+	if cfg.Here {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to get current directory", err)
+		}
+		cfg.Path, err = canonicalPath(cwd)
+		if err != nil {
+			return err
+		}
+		// Unless --name overrides it, prefer the name declared by an
+		// existing package manifest over the directory name
+		if cfg.Name == "" {
+			cfg.Name = config.DetectProjectName(cfg.Path)
+		}
+		if cfg.Name == "" {
+			cfg.Name = filepath.Base(cfg.Path)
+		}
+	} else {
+		if err := validateProjectName(cfg.Name); err != nil {
+			return err
+		}
+		absPath, err := filepath.Abs(cfg.Name)
+		if err != nil {
+			return errors.Wrap(errors.ErrCodeFileSystemError, "failed to resolve project path", err)
+		}
+		// The project directory doesn't exist yet, so canonicalize its parent
+		parent, err := canonicalPath(filepath.Dir(absPath))
+		if err != nil {
+			return err
+		}
+		cfg.Path = filepath.Join(parent, filepath.Base(absPath))
+	}
+
+	if cfg.CommandsOnly {
+		if !cfg.Here {
+			return errors.NewValidationError("--commands-only installs commands into an existing project; run it with --here")
+		}
+		if cfg.CleanBefore {
+			return errors.NewValidationError("--commands-only cannot be combined with --clean-before")
+		}
+	}
+
+	if cfg.DryRun && cfg.RetryStep != "" {
+		return errors.NewValidationError("--dry-run cannot be combined with --retry-step")
+	}
+
+	if err := validateBranch(cfg.Branch); err != nil {
+		return err
+	}
+	if cfg.GitAuthor != "" {
+		if err := validateGitAuthor(cfg.GitAuthor); err != nil {
+			return err
+		}
+	}
+	if cfg.Commit && cfg.NoGit {
+		return errors.NewValidationError("--commit cannot be combined with --no-git")
+	}
+	if cfg.GitCommitMessage != "" && strings.TrimSpace(cfg.GitCommitMessage) == "" {
+		return errors.NewValidationError("--git-commit-message cannot be blank")
+	}
+
+	if cfg.Proxy != "" {
+		if err := validateProxy(cfg.Proxy); err != nil {
+			return err
+		}
+	}
+	if cfg.GitHubAPIURL != "" {
+		if err := validateGitHubURL("--github-api-url", cfg.GitHubAPIURL, cfg.SkipTLS); err != nil {
+			return err
+		}
+	}
+	if cfg.Mirror != "" {
+		if err := validateGitHubURL("--mirror", cfg.Mirror, cfg.SkipTLS); err != nil {
+			return err
+		}
+	}
+
+	if cfg.TempDir != "" {
+		if err := validateTempDir(cfg.TempDir); err != nil {
+			return err
+		}
+	}
+
+	if cfg.LogLevel != "" && !slices.Contains(config.LogLevels, cfg.LogLevel) {
+		return errors.NewValidationError(fmt.Sprintf("invalid --log-level %q: expected %s",
+			cfg.LogLevel, strings.Join(config.LogLevels, ", ")))
+	}
+
+	if cfg.WriteConcurrency < 1 {
+		return errors.NewValidationError("--write-concurrency must be at least 1")
+	}
+
+	if cfg.Quiet && cfg.OutputFormat == config.OutputJSON {
+		return errors.NewValidationError("--quiet cannot be combined with --output json, which prints its own document")
+	}
+
+	if cfg.Timeout < 0 {
+		return errors.NewValidationError("--timeout must not be negative")
+	}
+	if cfg.CacheTTL < 0 {
+		return errors.NewValidationError("--cache-ttl must not be negative")
+	}
+
+	if err := validateOwner(cfg.Owner); err != nil {
+		return err
+	}
+
+	// Check the requested template set is embedded
+	if cfg.TemplateSet == "" {
+		cfg.TemplateSet = config.DefaultTemplateSet
+	}
+	if cfg.Ref != "" && cfg.From == "" {
+		return errors.NewValidationError("--ref requires --from")
+	}
+	if cfg.TemplateRef != "" && cfg.TemplateRepo == "" {
+		return errors.NewValidationError("--template-ref requires --template-repo")
+	}
+	if cfg.AllowSymlinks && cfg.TemplateRepo == "" && !cfg.UseRelease {
+		return errors.NewValidationError("--allow-symlinks requires --use-release or --template-repo")
+	}
+	if cfg.TemplateRepo != "" {
+		if err := validateTemplateRepo(cfg.TemplateRepo); err != nil {
+			return err
+		}
+		if cfg.TemplateSet != config.DefaultTemplateSet {
+			return errors.NewValidationError("--template-repo cannot be combined with --template-set")
+		}
+	}
+	if cfg.UseRelease && cfg.TemplateSet != config.DefaultTemplateSet {
+		return errors.NewValidationError("--use-release cannot be combined with --template-set")
+	}
+	if cfg.TemplateDir != "" {
+		if cfg.From != "" || cfg.TemplateRepo != "" || cfg.UseRelease {
+			return errors.NewValidationError("--template-dir cannot be combined with --from, --template-repo or --use-release")
+		}
+		if cfg.TemplateSet != config.DefaultTemplateSet {
+			return errors.NewValidationError("--template-dir cannot be combined with --template-set")
+		}
+		dir, err := validateTemplateDir(cfg.TemplateDir)
+		if err != nil {
+			return err
+		}
+		cfg.TemplateDir = dir
+	} else if cfg.From != "" {
+		if cfg.TemplateSet != config.DefaultTemplateSet {
+			return errors.NewValidationError("--from cannot be combined with --template-set")
+		}
+	} else if sets := templates.ListTemplateSets(); !slices.Contains(sets, cfg.TemplateSet) {
+		return errors.NewValidationError(
+			fmt.Sprintf("Unknown template set: %s (available: %s)", cfg.TemplateSet, strings.Join(sets, ", ")))
+	}
+
+	// Check if directory exists - only relevant when creating new project directory
+	if !cfg.Here && cfg.RetryStep == "" {
+		// When not using --here, we're creating a new directory that shouldn't exist
+		if _, err := os.Lstat(cfg.Path); err == nil {
+			return errors.NewValidationError(
+				fmt.Sprintf("Directory %s already exists", cfg.Path))
+		}
+	}
+	// When using --here, the current directory should exist and we don't need to check
+
+	return nil
+}
+
+// maxOwnerLength bounds --owner so it stays a name rather than a paragraph
+const maxOwnerLength = 100
+
+// validateProxy checks that proxy is an absolute http, https or socks5 URL
+func validateProxy(proxy string) error {
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || proxyURL.Host == "" {
+		return errors.NewValidationError(fmt.Sprintf("--proxy %q is not a valid URL (e.g. http://proxy.example.com:8080)", proxy))
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+		return nil
+	default:
+		return errors.NewValidationError(fmt.Sprintf("--proxy scheme %q is not supported (use http, https or socks5)", proxyURL.Scheme))
+	}
+}
+
+// validateGitHubURL checks that the value of flag is an absolute https URL,
+// or http when TLS verification is skipped anyway
+func validateGitHubURL(flag, value string, skipTLS bool) error {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return errors.NewValidationError(fmt.Sprintf("%s %q is not a valid base URL (e.g. https://github.example.com)", flag, value))
+	}
+	switch {
+	case parsed.Scheme == "https":
+		return nil
+	case parsed.Scheme == "http" && skipTLS:
+		return nil
+	case parsed.Scheme == "http":
+		return errors.NewValidationError(fmt.Sprintf("%s must use https (or pass --skip-tls to allow http)", flag))
+	default:
+		return errors.NewValidationError(fmt.Sprintf("%s scheme %q is not supported (use https)", flag, parsed.Scheme))
+	}
+}
+
+// validateTempDir checks that dir exists and accepts new files
+func validateTempDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return errors.NewValidationError(fmt.Sprintf("--temp-dir %s does not exist", dir))
+	}
+	if !info.IsDir() {
+		return errors.NewValidationError(fmt.Sprintf("--temp-dir %s is not a directory", dir))
+	}
+
+	probe, err := os.CreateTemp(dir, "gospecify-probe-*")
+	if err != nil {
+		return errors.NewValidationError(fmt.Sprintf("--temp-dir %s is not writable: %v", dir, err))
+	}
+	_ = probe.Close()
+	_ = os.Remove(probe.Name())
+	return nil
+}
+
+// templateDirAssets is the directory below --template-dir holding the
+// templates/ and scripts/ trees, as in the gospecify source
+const templateDirAssets = "assets"
+
+// validateTemplateDir checks that dir has the assets/templates and
+// assets/scripts layout and returns its absolute path
+func validateTemplateDir(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrCodeFileSystemError, "failed to resolve --template-dir", err)
+	}
+	if info, err := os.Stat(absDir); err != nil || !info.IsDir() {
+		return "", errors.NewValidationError(fmt.Sprintf("--template-dir %s is not a directory", dir))
+	}
+	assetsDir := filepath.Join(absDir, templateDirAssets)
+	if info, err := os.Stat(assetsDir); err != nil || !info.IsDir() {
+		return "", errors.NewValidationError(fmt.Sprintf(
+			"--template-dir %s has no %s/ directory (expected %s/templates and %s/scripts)", dir, templateDirAssets, templateDirAssets, templateDirAssets))
+	}
+	for _, sub := range []string{config.DefaultTemplateDir, config.DefaultScriptDir} {
+		if info, err := os.Stat(filepath.Join(assetsDir, sub)); err != nil || !info.IsDir() {
+			return "", errors.NewValidationError(fmt.Sprintf(
+				"--template-dir %s has no %s/%s directory", dir, templateDirAssets, sub))
+		}
+	}
+	return absDir, nil
+}
+
+// windowsReservedNames are device names Windows refuses as file names, with or without an extension
+var windowsReservedNames = []string{
+	"CON", "PRN", "AUX", "NUL",
+	"COM1", "COM2", "COM3", "COM4", "COM5", "COM6", "COM7", "COM8", "COM9",
+	"LPT1", "LPT2", "LPT3", "LPT4", "LPT5", "LPT6", "LPT7", "LPT8", "LPT9",
+}
+
+// validateProjectName checks that name is a single directory name usable on
+// every platform, since projects are often shared between them
+func validateProjectName(name string) error {
+	if name == "" {
+		return errors.NewValidationError("a project name is required (or use --here)")
+	}
+	if strings.ContainsAny(name, `/\`) {
+		return errors.NewValidationError(fmt.Sprintf(
+			"project name %q must not contain path separators; create the parent directory and run init from there", name))
+	}
+	if strings.HasPrefix(name, ".") {
+		return errors.NewValidationError(fmt.Sprintf(
+			"project name %q must not start with a dot; use --here to initialize the current directory", name))
+	}
+	for _, r := range name {
+		if unicode.IsControl(r) {
+			return errors.NewValidationError(fmt.Sprintf("project name %q must not contain control characters", name))
+		}
+	}
+	base, _, _ := strings.Cut(name, ".")
+	for _, reserved := range windowsReservedNames {
+		if strings.EqualFold(strings.TrimRight(base, " "), reserved) {
+			return errors.NewValidationError(fmt.Sprintf(
+				"project name %q is a reserved device name on Windows; choose another name", name))
+		}
+	}
+	return nil
+}
+
+// validateOwner checks the owner is a short single-line string
+func validateOwner(owner string) error {
+	if owner == "" {
+		return nil
+	}
+	if strings.TrimSpace(owner) != owner {
+		return errors.NewValidationError("--owner must not have leading or trailing whitespace")
+	}
+	if utf8.RuneCountInString(owner) > maxOwnerLength {
+		return errors.NewValidationError(fmt.Sprintf("--owner must be at most %d characters", maxOwnerLength))
+	}
+	for _, r := range owner {
+		if unicode.IsControl(r) {
+			return errors.NewValidationError("--owner must be a single line without control characters")
+		}
+	}
+	return nil
+}
+
+// ProjectReplacements returns the project-specific placeholder values for
+// generated files. --set values are merged last, except those targeting a
+// reserved placeholder, which reservedValues reports instead.
+func ProjectReplacements(cfg *config.ProjectConfig) map[string]string {
+	replacements := make(map[string]string)
+	if cfg.Owner != "" {
+		replacements["{{owner}}"] = cfg.Owner
+	}
+	for key, value := range cfg.Values {
+		placeholder := setPlaceholder(key)
+		if !slices.Contains(templates.ReservedPlaceholders, placeholder) {
+			replacements[placeholder] = value
+		}
+	}
+	return replacements
+}
+
+// setPlaceholder returns the placeholder a --set key fills: {{key}}, or the
+// key itself when it spells out a reserved placeholder such as __AGENT__
+func setPlaceholder(key string) string {
+	if slices.Contains(templates.ReservedPlaceholders, key) {
+		return key
+	}
+	return "{{" + key + "}}"
+}
+
+// reservedValues returns the sorted --set keys that target reserved placeholders
+func reservedValues(cfg *config.ProjectConfig) []string {
+	var reserved []string
+	for key := range cfg.Values {
+		if placeholder := setPlaceholder(key); slices.Contains(templates.ReservedPlaceholders, placeholder) {
+			reserved = append(reserved, key)
+		}
+	}
+	sort.Strings(reserved)
+	return reserved
+}
+
+// canonicalPath resolves symlinks so later path comparisons use a single canonical form
+func canonicalPath(path string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrCodeFileSystemError, fmt.Sprintf("failed to resolve symlinks in %s", path), err)
+	}
+	return resolved, nil
+}
+
+// selectAssistants resolves --ai all to every supported assistant, and any
+// other value to the single selected assistant
+func selectAssistants(cfg *config.ProjectConfig, renderer ui.OutputRenderer) ([]*config.AIAssistant, error) {
+	if cfg.AIAssistant != config.AIAssistantAll {
+		assistant, err := selectAssistant(cfg, renderer)
+		if err != nil {
+			return nil, err
+		}
+		return []*config.AIAssistant{assistant}, nil
+	}
+
+	keys := config.AllAssistantKeys()
+	assistants := make([]*config.AIAssistant, 0, len(keys))
+	for _, key := range keys {
+		assistant := config.AIAssistants[key]
+		assistants = append(assistants, &assistant)
+	}
+	return assistants, nil
+}
+
+// priorAssistants returns the sorted keys of the assistants, other than the
+// selected ones, that an existing .specify/ in projectPath was set up for
+func priorAssistants(projectPath string, assistants []*config.AIAssistant) []string {
+	if info, err := os.Stat(filepath.Join(projectPath, ".specify")); err != nil || !info.IsDir() {
+		return nil
+	}
+
+	selected := assistantKeys(assistants)
+	candidates := config.DetectAssistants(projectPath)
+	if manifest.Exists(projectPath) {
+		if previous, err := manifest.Load(projectPath); err == nil && previous.AIAssistant != "" {
+			candidates = append(candidates, previous.AIAssistant)
+		}
+	}
+
+	var prior []string
+	for _, key := range candidates {
+		if _, known := config.AIAssistants[key]; known && !slices.Contains(selected, key) && !slices.Contains(prior, key) {
+			prior = append(prior, key)
+		}
+	}
+	sort.Strings(prior)
+	return prior
+}
+
+// assistantKeys returns the keys of assistants in order
+func assistantKeys(assistants []*config.AIAssistant) []string {
+	keys := make([]string, 0, len(assistants))
+	for _, assistant := range assistants {
+		keys = append(keys, assistant.Key)
+	}
+	return keys
+}
+
+// assistantDirectories returns the command directories of assistants in order
+func assistantDirectories(assistants []*config.AIAssistant) []string {
+	dirs := make([]string, 0, len(assistants))
+	for _, assistant := range assistants {
+		dirs = append(dirs, assistant.Directory)
+	}
+	return dirs
+}
+
+// selectAssistant selects the AI assistant to use
+func selectAssistant(cfg *config.ProjectConfig, renderer ui.OutputRenderer) (*config.AIAssistant, error) {
+	// Re-initializing in place should refresh the assistant already set up
+	var detected []string
+	if cfg.Here {
+		detected = config.DetectAssistants(cfg.Path)
+	}
+
+	if cfg.AIAssistant != "" {
+		assistant, exists := config.AIAssistants[cfg.AIAssistant]
+		if !exists {
+			return nil, errors.NewValidationError(
+				fmt.Sprintf("Unknown AI assistant: %s", cfg.AIAssistant))
+		}
+		if len(detected) > 0 && !slices.Contains(detected, assistant.Key) {
+			renderer.Warn(fmt.Sprintf("this directory is already set up for %s; --ai %s will add a second assistant",
+				strings.Join(detected, ", "), assistant.Key))
+		}
+		return &assistant, nil
+	}
+
+	if len(detected) == 1 {
+		assistant := config.AIAssistants[detected[0]]
+		return &assistant, nil
+	}
+
+	// Interactive selection, pre-selecting a detected assistant if there is one
+	defaultKey := config.DefaultAIAssistant
+	if len(detected) > 0 {
+		defaultKey = detected[0]
+	}
+
+	if cfg.OutputFormat == config.OutputJSON {
+		return nil, errors.NewValidationError("--ai is required with --output json, which cannot prompt")
+	}
+	if !promptsAllowed(cfg) {
+		renderer.Info(fmt.Sprintf("Running non-interactively: using the default AI assistant %s (pass --ai to choose another)", defaultKey))
+		assistant := config.AIAssistants[defaultKey]
+		return &assistant, nil
+	}
+
+	selector := ui.NewSelector("Select your AI assistant", config.AIChoices, defaultKey).WithAccessible(cfg.Accessible)
+	pauseProgress(renderer)
+	selected, err := selector.Run()
+	if err != nil {
+		if errors.CodeOf(err) == errors.ErrCodeCancelled {
+			return nil, errors.NewCancelled("AI assistant selection cancelled (pass --ai to choose one non-interactively)")
+		}
+		return nil, errors.Wrap(errors.ErrCodeValidationError, "assistant selection failed", err)
+	}
+
+	assistant := config.AIAssistants[selected]
+	return &assistant, nil
+}
+
+// selectScriptType selects the script type to use, pre-selecting the assistant's preferred type
+func selectScriptType(cfg *config.ProjectConfig, assistant *config.AIAssistant, renderer ui.OutputRenderer) (string, error) {
+	if cfg.ScriptType != "" {
+		if _, exists := config.ScriptTypes[cfg.ScriptType]; !exists {
+			return "", errors.NewValidationError(
+				fmt.Sprintf("Unknown script type: %s", cfg.ScriptType))
+		}
+		return cfg.ScriptType, nil
+	}
+
+	if !promptsAllowed(cfg) {
+		if cfg.NonInteractive {
+			renderer.Info(fmt.Sprintf("Running non-interactively: using the default script type %s (pass --script to choose another)",
+				assistant.PreferredScriptType()))
+		}
+		return assistant.PreferredScriptType(), nil
+	}
+
+	// Interactive selection
+	scriptChoices := make(map[string]string)
+	for key, scriptType := range config.ScriptTypes {
+		scriptChoices[key] = scriptType.Name
+	}
+
+	selector := ui.NewSelector("Select your script type", scriptChoices, assistant.PreferredScriptType()).
+		WithAccessible(cfg.Accessible)
+	pauseProgress(renderer)
+	selected, err := selector.Run()
+	if err != nil {
+		if errors.CodeOf(err) == errors.ErrCodeCancelled {
+			return "", errors.NewCancelled("script type selection cancelled (pass --script to choose one non-interactively)")
+		}
+		return "", errors.Wrap(errors.ErrCodeValidationError, "script type selection failed", err)
+	}
+
+	return selected, nil
+}
+
+// checkRequiredTools checks that required tools are available. With several
+// assistants nobody has every CLI installed, so missing ones are only warned about.
+func checkRequiredTools(assistants []*config.AIAssistant, ignoreTools bool, renderer ui.OutputRenderer) error {
+	if ignoreTools {
+		return nil
+	}
+
+	// Check for git (optional)
+	if _, err := exec.LookPath("git"); err != nil {
+		renderer.Warn("git not found. Consider installing git for version control.")
+	}
+
+	// Check for AI assistant CLI tools (if required)
+	var missing []string
+	for _, assistant := range assistants {
+		if assistant.CLITool == "" {
+			continue
+		}
+		if _, err := exec.LookPath(assistant.CLITool); err != nil {
+			if len(assistants) == 1 {
+				return errors.NewToolNotFound(assistant.CLITool)
+			}
+			missing = append(missing, assistant.CLITool)
+		}
+	}
+	if len(missing) > 0 {
+		renderer.Warn(fmt.Sprintf("assistant CLIs not found: %s", strings.Join(missing, ", ")))
+	}
+
+	return nil
+}
+
+// processTemplates processes templates from embedded assets and creates project structure,
+// reporting the number of files written through progressFn when it is non-nil
+func processTemplates(cfg *config.ProjectConfig, assets *templates.EmbeddedAssets, assistants []*config.AIAssistant, writer *ProjectWriter, renderer ui.OutputRenderer, progressFn func(int, int)) error {
+	projectPath := cfg.Path
+
+	// Create base project structure
+	dirs := []string{
+		".specify/templates",
+		".specify/templates/commands",
+	}
+	if cfg.CommandsOnly {
+		dirs = nil
+	}
+	dirs = append(dirs, assistantDirectories(assistants)...)
+
+	for _, dir := range dirs {
+		if err := writer.mkdirAll(dir); err != nil {
+			return err
+		}
+	}
+
+	// Each assistant gets its own pass since argument formats and file
+	// formats differ; .specify/templates comes from the first assistant.
+	// The command files are resolved up front so conflicts abort before
+	// anything is written.
+	if mixed := templates.MixedLineEndings(assets); len(mixed) > 0 {
+		if cfg.FixLineEndings {
+			renderer.Info(fmt.Sprintf("Normalized mixed line endings to LF in:\n  %s", strings.Join(mixed, "\n  ")))
+		} else {
+			renderer.Warn(fmt.Sprintf("templates mix CRLF and LF line endings, which can break processing (use --fix-line-endings to normalize them):\n  %s",
+				strings.Join(mixed, "\n  ")))
+		}
+	}
+
+	var processedTemplates map[string][]byte
+	commandFiles := make(map[string][]byte)
+	for i, assistant := range assistants {
+		processed, err := templates.NewProcessor(assets, assistant, cfg.ScriptType).
+			WithReplacements(ProjectReplacements(cfg)).
+			WithLineEndingFix(cfg.FixLineEndings).
+			WithTemplateStructure(cfg.KeepTemplateStructure).
+			WithAgents(SharedAgents(assistantKeys(assistants))).
+			ProcessAllTemplates()
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			processedTemplates = processed
+		}
+
+		// The full set stays in .specify/templates/commands so more can be enabled later
+		selected, err := selectCommands(processed, cfg.Commands)
+		if err != nil {
+			return err
+		}
+
+		// Without a single command the assistant has nothing to run
+		assistantFiles := assistantCommandFiles(selected, assistant)
+		if len(assistantFiles) == 0 {
+			return errors.NewTemplateError(fmt.Sprintf(
+				"no command templates were produced for %s (%s format); the template assets do not match this assistant",
+				assistant.Name, assistant.Format), nil)
+		}
+		for commandPath, content := range assistantFiles {
+			commandFiles[commandPath] = content
+		}
+	}
+
+	// Names differing only in case would overwrite each other on macOS and Windows
+	outputPaths := make([]string, 0, len(processedTemplates)+len(commandFiles))
+	for templateName := range processedTemplates {
+		outputPaths = append(outputPaths, path.Join(".specify/templates", templateName))
+	}
+	for commandPath := range commandFiles {
+		outputPaths = append(outputPaths, commandPath)
+	}
+	if collisions := detectCaseCollisions(outputPaths); len(collisions) > 0 {
+		return errors.NewTemplateError(fmt.Sprintf(
+			"template paths differ only by letter case and would overwrite each other on case-insensitive filesystems:\n  %s",
+			strings.Join(collisions, "\n  ")), nil)
+	}
+
+	if !cfg.Force {
+		if conflicts := detectConflicts(projectPath, commandFiles); len(conflicts) > 0 {
+			return errors.NewValidationError(fmt.Sprintf(
+				"existing command files differ from the templates and would be overwritten (use --force to overwrite):\n  %s",
+				strings.Join(conflicts, "\n  ")))
+		}
+	}
+
+	// .specify/templates is left alone when only the commands are reinstalled
+	if cfg.CommandsOnly {
+		processedTemplates = nil
+	}
+
+	// Every template is written once, and commands a second time into the assistant folder
+	written, total := 0, len(processedTemplates)+len(commandFiles)
+	reportProgress := func() {
+		written++
+		if progressFn != nil {
+			progressFn(written, total)
+		}
+	}
+
+	files := make([]pendingFile, 0, total)
+	for templateName, content := range processedTemplates {
+		files = append(files, pendingFile{relPath: path.Join(".specify/templates", templateName), content: content, perm: 0644})
+	}
+
+	// Copy command templates to assistant folder
+	for commandPath, content := range commandFiles {
+		files = append(files, pendingFile{relPath: commandPath, content: content, perm: 0644})
+	}
+
+	if err := writer.writeAll(files, cfg.WriteConcurrency, reportProgress); err != nil {
+		return err
+	}
+
+	if cfg.CommandsOnly {
+		commandPaths := make([]string, 0, len(commandFiles))
+		for commandPath := range commandFiles {
+			commandPaths = append(commandPaths, commandPath)
+		}
+		sort.Strings(commandPaths)
+		verb := "Wrote"
+		if cfg.DryRun {
+			verb = "Would write"
+		}
+		renderer.Info(fmt.Sprintf("%s %d command files:\n  %s", verb, len(commandPaths), strings.Join(commandPaths, "\n  ")))
+	}
+
+	return nil
+}
+
+// detectConflicts returns the sorted project-relative paths that already exist
+// with content different from what would be written
+func detectConflicts(projectPath string, files map[string][]byte) []string {
+	var conflicts []string
+	for relPath, content := range files {
+		existing, err := os.ReadFile(filepath.Join(projectPath, filepath.FromSlash(relPath)))
+		if err == nil && !bytes.Equal(existing, content) {
+			conflicts = append(conflicts, relPath)
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// detectCaseCollisions returns a sorted description of each group of paths that
+// are equal when compared case-insensitively, e.g. "Plan.md, plan.md"
+func detectCaseCollisions(paths []string) []string {
+	groups := make(map[string][]string)
+	for _, p := range paths {
+		folded := strings.ToLower(p)
+		groups[folded] = append(groups[folded], p)
+	}
+
+	var collisions []string
+	for _, group := range groups {
+		if len(group) > 1 {
+			sort.Strings(group)
+			collisions = append(collisions, strings.Join(group, ", "))
+		}
+	}
+	sort.Strings(collisions)
+	return collisions
+}
+
+// generateScripts generates the setup scripts, reporting the number of
+// scripts written through progressFn when it is non-nil
+func generateScripts(cfg *config.ProjectConfig, assets *templates.EmbeddedAssets, assistants []*config.AIAssistant, writer *ProjectWriter, renderer ui.OutputRenderer, progressFn func(int, int)) error {
+	scriptType := cfg.ScriptType
+	var err error
+
+	// Create script generator
+	generator := scripts.NewGenerator(assets, assistants[0], scriptType).
+		WithReplacements(ProjectReplacements(cfg)).
+		WithAgents(SharedAgents(assistantKeys(assistants)))
+
+	// Generate all scripts, keyed by their path below the scripts directory
+	var generatedScripts map[string][]byte
+	if cfg.KeepTemplateStructure {
+		// Mirror the template archive, e.g. scripts/bash/setup-plan.sh
+		if generatedScripts, err = generator.GenerateScriptTree(); err != nil {
+			return err
+		}
+	} else {
+		flatScripts, err := generator.GenerateAllScripts()
+		if err != nil {
+			return err
+		}
+		generatedScripts = make(map[string][]byte, len(flatScripts))
+		for scriptName, content := range flatScripts {
+			generatedScripts[scriptName+scripts.GetScriptExtension(scriptType)] = content
+		}
+	}
+
+	// Prefer the project's own versions of scripts where it ships them
+	customScripts, err := mergeCustomScripts(cfg.Path, scriptType, generatedScripts)
+	if err != nil {
+		return err
+	}
+	if len(customScripts) > 0 {
+		renderer.Info(fmt.Sprintf("Using %d custom scripts from %s: %s (%d embedded)",
+			len(customScripts), customScriptsDir, strings.Join(customScripts, ", "),
+			len(generatedScripts)-len(customScripts)))
+	}
+
+	// Write scripts to project directory, and optionally into the assistant
+	// folder for agents that cannot reach outside it
+	dirs := []string{".specify/scripts"}
+	if cfg.CopyScriptsToAgent {
+		for _, assistant := range assistants {
+			dirs = append(dirs, path.Join(assistant.Directory, "scripts"))
+		}
+	}
+
+	written, total := 0, len(generatedScripts)*len(dirs)
+	for scriptName, content := range generatedScripts {
+		for _, dir := range dirs {
+			scriptPath := path.Join(dir, scriptName)
+			if err := writer.WriteFile(scriptPath, content, 0755); err != nil {
+				return err
+			}
+
+			written++
+			if progressFn != nil {
+				progressFn(written, total)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkDiskSpace verifies the project filesystem has room for every generated file
+func checkDiskSpace(cfg *config.ProjectConfig, assets *templates.EmbeddedAssets, assistants []*config.AIAssistant) error {
+	files := make(map[string][]byte)
+	for _, assistant := range assistants {
+		assistantFiles, err := ExpectedProjectFiles(assets, assistant, cfg.ScriptType, ProjectReplacements(cfg), SharedAgents(assistantKeys(assistants)), cfg.Commands, cfg.KeepTemplateStructure)
+		if err != nil {
+			return err
+		}
+		for relPath, content := range assistantFiles {
+			files[relPath] = content
+		}
+	}
+
+	sizes := make([]int64, 0, len(files))
+	for relPath, content := range files {
+		sizes = append(sizes, int64(len(content)))
+		if cfg.CopyScriptsToAgent && strings.HasPrefix(relPath, ".specify/scripts/") {
+			for range assistants {
+				sizes = append(sizes, int64(len(content)))
+			}
+		}
+	}
+
+	if err := diskspace.Check(cfg.Path, diskspace.Estimate(sizes...)); err != nil {
+		if errors.CodeOf(err) == errors.ErrCodeDiskSpace {
+			return err
+		}
+		// Not being able to measure free space shouldn't block initialization
+		debugf(cfg, "skipping disk space check: %v", err)
+	}
+	return nil
+}
+
+// editorConfigDir is the project-relative directory editor recommendation files are written to
+const editorConfigDir = ".vscode"
+
+// writeEditorConfig writes the embedded editor recommendation files for an
+// assistant (only GitHub Copilot has any), returning how many were written.
+// Existing files are left alone unless --force is set.
+func writeEditorConfig(cfg *config.ProjectConfig, assistant *config.AIAssistant, writer *ProjectWriter, renderer ui.OutputRenderer) (int, error) {
+	files, err := templates.EditorFiles(assistant.Key)
+	if err != nil {
+		return 0, err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	written := 0
+	for _, name := range names {
+		relPath := path.Join(editorConfigDir, name)
+		if _, err := os.Stat(filepath.Join(cfg.Path, filepath.FromSlash(relPath))); err == nil && !cfg.Force {
+			renderer.Warn(fmt.Sprintf("%s already exists; leaving it unchanged (use --force to overwrite)", relPath))
+			continue
+		}
+		if err := writer.WriteFile(relPath, files[name], 0644); err != nil {
+			return written, err
+		}
+		written++
+	}
+
+	if written > 0 {
+		if err := writer.saveManifest(); err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// customScriptsDir is the project-relative directory whose scripts replace the embedded ones
+const customScriptsDir = ".specify/scripts.custom"
+
+// mergeCustomScripts overlays scripts from customScriptsDir onto generated, keyed the
+// same way, and returns the sorted names that came from the custom directory
+func mergeCustomScripts(projectPath, scriptType string, generated map[string][]byte) ([]string, error) {
+	customRoot := filepath.Join(projectPath, filepath.FromSlash(customScriptsDir))
+	if info, err := os.Stat(customRoot); err != nil || !info.IsDir() {
+		return nil, nil
+	}
+
+	extension := scripts.GetScriptExtension(scriptType)
+	var custom []string
+	err := filepath.WalkDir(customRoot, func(filePath string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(filePath) != extension {
+			return err
+		}
+
+		relPath, err := filepath.Rel(customRoot, filePath)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+
+		name := filepath.ToSlash(relPath)
+		generated[name] = content
+		custom = append(custom, name)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeFileSystemError, "failed to read custom scripts", err)
+	}
+
+	sort.Strings(custom)
+	return custom, nil
+}
+
+// initializeGit initializes a git repository with an initial commit. When retrying
+// the git step, a repository left behind by the failed run is committed to rather than skipped.
+func initializeGit(projectPath string, cfg *config.ProjectConfig) error {
+	if cfg.NoGit {
+		return nil
+	}
+
+	// Check if already a git repository
+	if _, err := os.Stat(filepath.Join(projectPath, ".git")); err == nil {
+		if cfg.RetryStep != "git" {
+			return nil // Already a git repo
+		}
+	} else if err := gitInit(cfg.Logger, projectPath, cfg.Branch); err != nil {
+		return err
+	}
+
+	// Create initial commit. Everything in the project is staged, so any
+	// .gitignore entries for agent folders must be written before this point
+	// or their contents end up in the first commit.
+	cmd := gitCommand(logger(cfg), projectPath, "add", ".")
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(errors.ErrCodeGitError, "failed to add files to git", err)
+	}
+
+	// Record +x in the index, since git on Windows does not see it on disk
+	if !cfg.NoGitChmod {
+		shellScripts, err := FindShellScripts(projectPath)
+		if err != nil {
+			return err
+		}
+		if len(shellScripts) > 0 {
+			cmd = gitCommand(logger(cfg), projectPath, append([]string{"update-index", "--chmod=+x", "--"}, shellScripts...)...)
+			if err := cmd.Run(); err != nil {
+				return errors.Wrap(errors.ErrCodeGitError, "failed to mark scripts executable in git", err)
+			}
+		}
+	}
+
+	cmd = gitCommand(logger(cfg), projectPath, commitArgs(cfg, config.DefaultCommitMessage)...)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(errors.ErrCodeGitError, "failed to create initial commit", err)
+	}
+
+	return nil
+}
+
+// gitInit creates a repository whose HEAD points at branch. git init -b needs
+// git 2.28 or later, so older versions get HEAD repointed after a plain init.
+func gitInit(log *slog.Logger, projectPath, branch string) error {
+	if gitCommand(log, projectPath, "init", "-b", branch).Run() == nil {
+		return nil
+	}
+
+	log.Debug("git init -b failed, setting the branch with symbolic-ref")
+	if err := gitCommand(log, projectPath, "init").Run(); err != nil {
+		return errors.Wrap(errors.ErrCodeGitError, "failed to initialize git repository", err)
+	}
+	cmd := gitCommand(log, projectPath, "symbolic-ref", "HEAD", "refs/heads/"+branch)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(errors.ErrCodeGitError, "failed to set the initial branch to "+branch, err)
+	}
+	return nil
+}
+
+// validateBranch rejects branch names git would refuse, following git check-ref-format
+func validateBranch(branch string) error {
+	invalid := branch == "" || branch == "@" || strings.HasPrefix(branch, "-") ||
+		strings.HasPrefix(branch, "/") || strings.HasSuffix(branch, "/") ||
+		strings.HasSuffix(branch, ".") || strings.HasSuffix(branch, ".lock") ||
+		strings.Contains(branch, "..") || strings.Contains(branch, "//") ||
+		strings.Contains(branch, "@{") || strings.Contains(branch, "/.") || strings.HasPrefix(branch, ".")
+	for _, r := range branch {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(" ~^:?*[\\", r) {
+			invalid = true
+		}
+	}
+	if invalid {
+		return errors.NewValidationError(fmt.Sprintf("--branch %q is not a valid git branch name", branch))
+	}
+	return nil
+}
+
+// commitArgs returns the git arguments for a commit, applying --git-author
+// and --git-commit-message, which overrides defaultMessage, when given
+func commitArgs(cfg *config.ProjectConfig, defaultMessage string) []string {
+	var args []string
+	if cfg.GitAuthor != "" {
+		// validateConfig already rejected authors that do not parse
+		if author, err := mail.ParseAddress(cfg.GitAuthor); err == nil {
+			args = append(args, "-c", "user.name="+author.Name, "-c", "user.email="+author.Address)
+		}
+	}
+
+	message := cfg.GitCommitMessage
+	if message == "" {
+		message = defaultMessage
+	}
+	return append(args, "commit", "-m", message)
+}
+
+// gitRemoteURL returns the URL of the origin remote of the repository in projectPath, or ""
+func gitRemoteURL(cfg *config.ProjectConfig, projectPath string) string {
+	output, err := gitCommand(logger(cfg), projectPath, "remote", "get-url", "origin").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// commitGeneratedFiles stages the generated paths in an existing repository
+// and commits them. Paths matched by .gitignore, such as an ignored agent
+// folder, are left out and returned rather than forced in. It returns how
+// many files the commit changed, which is 0 when there was nothing new.
+func commitGeneratedFiles(cfg *config.ProjectConfig, projectPath string, paths []string) (int, []string, error) {
+	log := logger(cfg)
+
+	// check-ignore exits 1 when nothing is ignored, so only its output counts
+	check := gitCommand(log, projectPath, "check-ignore", "--stdin")
+	check.Stdin = strings.NewReader(strings.Join(paths, "\n") + "\n")
+	output, _ := check.Output()
+	ignored := strings.FieldsFunc(string(output), func(r rune) bool { return r == '\n' })
+
+	var staged []string
+	for _, relPath := range paths {
+		if !slices.Contains(ignored, relPath) {
+			staged = append(staged, relPath)
+		}
+	}
+	if len(staged) == 0 {
+		return 0, ignored, nil
+	}
+
+	if err := gitCommand(log, projectPath, append([]string{"add", "--"}, staged...)...).Run(); err != nil {
+		return 0, ignored, errors.Wrap(errors.ErrCodeGitError, "failed to stage the generated files", err)
+	}
+
+	changed, err := gitCommand(log, projectPath, append([]string{"diff", "--cached", "--name-only", "--"}, staged...)...).Output()
+	if err != nil {
+		return 0, ignored, errors.Wrap(errors.ErrCodeGitError, "failed to list the staged files", err)
+	}
+	count := len(strings.Fields(string(changed)))
+	if count == 0 {
+		return 0, ignored, nil
+	}
+
+	// Commit only the generated paths, leaving anything the user had staged alone
+	args := append(commitArgs(cfg, config.DefaultAddCommitMessage), "--")
+	if err := gitCommand(log, projectPath, append(args, staged...)...).Run(); err != nil {
+		return 0, ignored, errors.Wrap(errors.ErrCodeGitError, "failed to commit the generated files", err)
+	}
+	return count, ignored, nil
+}
+
+// validateGitAuthor checks that author has the "Name <email>" form git expects
+func validateGitAuthor(author string) error {
+	parsed, err := mail.ParseAddress(author)
+	if err != nil || parsed.Name == "" {
+		return errors.NewValidationError(
+			fmt.Sprintf("--git-author %q must look like \"Jane Doe <jane@example.com>\"", author))
+	}
+	return nil
+}
+
+// executableScriptExtensions are the script extensions run through a shebang
+var executableScriptExtensions = map[string]bool{".sh": true, ".fish": true, ".nu": true}
+
+// FindShellScripts returns the slash-separated paths of the scripts below
+// .specify/scripts that are run directly and so need the executable bit:
+// everything but PowerShell
+func FindShellScripts(projectPath string) ([]string, error) {
+	scriptsDir := filepath.Join(projectPath, ".specify", "scripts")
+	if _, err := os.Stat(scriptsDir); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var shellScripts []string
+	err := filepath.WalkDir(scriptsDir, func(fullPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !executableScriptExtensions[filepath.Ext(entry.Name())] {
+			return nil
+		}
+		relPath, err := filepath.Rel(projectPath, fullPath)
+		if err != nil {
+			return err
+		}
+		shellScripts = append(shellScripts, filepath.ToSlash(relPath))
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeFileSystemError, "failed to list generated scripts", err)
+	}
+	return shellScripts, nil
+}
+
+// prepareProjectDirectory creates the project directory structure without GitHub download
+func prepareProjectDirectory(cfg *config.ProjectConfig) (string, error) {
+	// cfg.Path was canonicalized by validateConfig
+	projectPath := cfg.Path
+
+	if cfg.Here {
+		// Check if directory is empty or force flag is set
+		entries, err := os.ReadDir(projectPath)
+		if err != nil {
+			return "", errors.Wrap(errors.ErrCodeFileSystemError, "failed to read current directory", err)
+		}
+
+		// Reinstalling commands into an existing project is the point of
+		// --commands-only, and --clean-before asks before clearing a managed one
+		cleaning := cfg.CleanBefore && manifest.Exists(projectPath)
+		if len(entries) > 0 && !cfg.Force && !cfg.CommandsOnly && !cleaning && !cfg.DryRun {
+			return "", errors.New(errors.ErrCodeValidationError, "directory is not empty (use --force to override)")
+		}
+	} else {
+		// Check if directory already exists
+		if _, err := os.Lstat(projectPath); err == nil {
+			return "", errors.New(errors.ErrCodeValidationError, fmt.Sprintf("Directory %s already exists", projectPath))
+		}
+
+		// Create the project directory
+		if cfg.DryRun {
+			return projectPath, nil
+		}
+		if err := os.MkdirAll(projectPath, 0755); err != nil {
+			return "", errors.Wrap(errors.ErrCodeFileSystemError, "failed to create project directory", err)
+		}
+	}
+
+	return projectPath, nil
+}
+
+// generateCommandFileName generates the correct filename for a command template based on the assistant's format
+func generateCommandFileName(originalName string, assistant *config.AIAssistant) string {
+	// Extract base name without extension
+	baseName := strings.TrimSuffix(originalName, filepath.Ext(originalName))
+
+	// Apply assistant-specific format
+	switch assistant.Format {
+	case config.FormatPrompt:
+		return baseName + ".prompt.md"
+	case config.FormatTOML:
+		return baseName + ".toml"
+	case config.FormatMarkdown:
+		return baseName + ".md"
+	default:
+		return originalName // fallback to original name
+	}
+}
+
+// debugf logs a diagnostic message at debug level, shown with --debug or --log-level debug
+func debugf(cfg *config.ProjectConfig, format string, args ...any) {
+	logger(cfg).Debug(fmt.Sprintf(format, args...))
+}
+
+// pauseProgress keeps the live progress tree from redrawing over an
+// interactive selector until the running step changes
+func pauseProgress(renderer ui.OutputRenderer) {
+	if human, ok := renderer.(*ui.HumanRenderer); ok {
+		human.Pause()
+	}
+}
+
+// confirm asks a yes/no question on stdin without the live progress tree
+// being redrawn over the prompt
+func confirm(renderer ui.OutputRenderer, question string) (bool, error) {
+	if human, ok := renderer.(*ui.HumanRenderer); ok {
+		human.Interrupt()
+	}
+	return ui.Confirm(question)
+}
+
+// cleanManagedFiles removes the files listed in an existing project manifest,
+// asking for confirmation unless --force is set
+func cleanManagedFiles(cfg *config.ProjectConfig, renderer ui.OutputRenderer) error {
+	if !manifest.Exists(cfg.Path) {
+		renderer.Info("No manifest found, nothing to clean")
+		return nil
+	}
+
+	m, err := manifest.Load(cfg.Path)
+	if err != nil {
+		return err
+	}
+
+	var existing []string
+	for _, relPath := range m.Paths() {
+		if _, err := os.Lstat(filepath.Join(cfg.Path, filepath.FromSlash(relPath))); err == nil {
+			existing = append(existing, relPath)
+		}
+	}
+	if len(existing) == 0 {
+		renderer.Info("No previously managed files to clean")
+		return nil
+	}
+
+	if cfg.DryRun {
+		renderer.Info(fmt.Sprintf("Would remove %d previously generated files:\n  %s", len(existing), strings.Join(existing, "\n  ")))
+		return nil
+	}
+
+	if !cfg.Force {
+		if !promptsAllowed(cfg) {
+			return errors.NewValidationError("--clean-before needs --force when init cannot prompt (--yes, --output json or no terminal)")
+		}
+		confirmed, err := confirm(renderer, fmt.Sprintf("Remove %d previously generated files before re-scaffolding?", len(existing)))
+		if err != nil {
+			return errors.Wrap(errors.ErrCodeValidationError, "confirmation failed", err)
+		}
+		if !confirmed {
+			return errors.NewValidationError("clean aborted by user")
+		}
+	}
+
+	for _, relPath := range existing {
+		if err := os.Remove(filepath.Join(cfg.Path, filepath.FromSlash(relPath))); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(errors.ErrCodeFileSystemError, fmt.Sprintf("failed to remove %s", relPath), err)
+		}
+		renderer.Info(fmt.Sprintf("Removed %s", relPath))
+	}
+
+	return nil
+}
+
+// seedInitialSpec writes specs/001-initial/spec.md from the spec template,
+// filled in with the --describe text. It returns the project-relative path.
+func seedInitialSpec(cfg *config.ProjectConfig, assets *templates.EmbeddedAssets, journal *rollbackJournal) (string, error) {
+	const featureDir = "001-initial"
+	relPath := path.Join("specs", featureDir, "spec.md")
+	specPath := filepath.Join(cfg.Path, filepath.FromSlash(relPath))
+
+	// Never clobber a spec the user already has
+	if _, err := os.Stat(specPath); err == nil {
+		return "", errors.NewValidationError(fmt.Sprintf("%s already exists", relPath))
+	}
+	if cfg.DryRun {
+		return relPath, nil
+	}
+
+	template, exists := assets.GetTemplate("spec-template.md")
+	if !exists {
+		return "", errors.NewAssetNotFound("template spec-template.md")
+	}
+
+	content := strings.NewReplacer(
+		"[FEATURE NAME]", cfg.Describe,
+		"[###-feature-name]", featureDir,
+		"[DATE]", cfg.CreatedAt.Format("2006-01-02"),
+		"$ARGUMENTS", strings.ReplaceAll(cfg.Describe, `"`, `\"`),
+	).Replace(string(template))
+
+	journal.track(relPath)
+	if err := os.MkdirAll(filepath.Dir(specPath), 0755); err != nil {
+		return "", errors.Wrap(errors.ErrCodeFileSystemError, "failed to create spec directory", err)
+	}
+	if err := os.WriteFile(specPath, []byte(content), 0644); err != nil {
+		return "", errors.Wrap(errors.ErrCodeFileSystemError, "failed to write initial spec", err)
+	}
+
+	return relPath, nil
+}
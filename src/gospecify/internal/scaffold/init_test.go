@@ -0,0 +1,290 @@
+package scaffold
+
+import (
+	"context"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/manifest"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/templates"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/ui"
+)
+
+// newTestConfig returns the configuration of a non-interactive init of a
+// project named name, with the CLI's flag defaults
+func newTestConfig(t *testing.T, name string) *config.ProjectConfig {
+	t.Helper()
+	createdAt, err := config.ResolveTimestamp("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &config.ProjectConfig{
+		Name:             name,
+		AIAssistant:      "claude",
+		ScriptType:       config.ScriptTypeBash,
+		TemplateSet:      config.DefaultTemplateSet,
+		Branch:           config.DefaultBranch,
+		WriteConcurrency: config.DefaultWriteConcurrency,
+		LogLevel:         config.DefaultLogLevel,
+		CreatedAt:        createdAt,
+		NoGit:            true,
+		IgnoreTools:      true,
+		NonInteractive:   true,
+		Quiet:            true,
+	}
+}
+
+// runInit scaffolds cfg in a fresh temporary working directory and returns
+// the project path
+func runInit(t *testing.T, cfg *config.ProjectConfig) string {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	result, err := Run(context.Background(), cfg, ui.NewQuietRenderer())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	return result.Path
+}
+
+var scriptReference = regexp.MustCompile(`\.specify/scripts/[^\s"'` + "`" + `)]+\.sh`)
+
+func TestRunCommandScriptPathsExist(t *testing.T) {
+	for _, keep := range []bool{false, true} {
+		t.Run(map[bool]string{false: "flattened", true: "keep-template-structure"}[keep], func(t *testing.T) {
+			cfg := newTestConfig(t, "project")
+			cfg.KeepTemplateStructure = keep
+			projectPath := runInit(t, cfg)
+
+			commands, err := filepath.Glob(filepath.Join(projectPath, ".claude", "commands", "*.md"))
+			if err != nil || len(commands) == 0 {
+				t.Fatalf("no command files written (err = %v)", err)
+			}
+			references := 0
+			for _, command := range commands {
+				content, err := os.ReadFile(command)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, ref := range scriptReference.FindAllString(string(content), -1) {
+					references++
+					if _, err := os.Stat(filepath.Join(projectPath, filepath.FromSlash(ref))); err != nil {
+						t.Errorf("%s references %s, which was not written", filepath.Base(command), ref)
+					}
+				}
+			}
+			if references == 0 {
+				t.Error("no command file references a script")
+			}
+
+			_, err = os.Stat(filepath.Join(projectPath, ".specify", "scripts", "bash"))
+			if keep != (err == nil) {
+				t.Errorf("scripts/bash directory exists = %v, want %v", err == nil, keep)
+			}
+		})
+	}
+}
+
+func TestRunWritesCommonHelper(t *testing.T) {
+	for scriptType, helper := range map[string]string{
+		config.ScriptTypeBash:       "common.sh",
+		config.ScriptTypePowerShell: "common.ps1",
+		config.ScriptTypeFish:       "common.fish",
+		config.ScriptTypeNushell:    "common.nu",
+	} {
+		t.Run(scriptType, func(t *testing.T) {
+			cfg := newTestConfig(t, "project")
+			cfg.ScriptType = scriptType
+			projectPath := runInit(t, cfg)
+
+			if _, err := os.Stat(filepath.Join(projectPath, ".specify", "scripts", helper)); err != nil {
+				t.Errorf("%s was not written: %v", helper, err)
+			}
+		})
+	}
+}
+
+func TestRunHereInSymlinkedDirectory(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symlinks needs extra privileges on Windows")
+	}
+	base, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	real := filepath.Join(base, "real")
+	link := filepath.Join(base, "link")
+	if err := os.Mkdir(real, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(real, link); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(link)
+
+	cfg := newTestConfig(t, "")
+	cfg.Here = true
+	result, err := Run(context.Background(), cfg, ui.NewQuietRenderer())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if result.Path != real {
+		t.Errorf("project path = %s, want the resolved directory %s", result.Path, real)
+	}
+	if _, err := os.Stat(filepath.Join(real, ".specify", "scripts", "setup-plan.sh")); err != nil {
+		t.Errorf("scripts did not land in the resolved directory: %v", err)
+	}
+	if entries, _ := os.ReadDir(base); len(entries) != 2 {
+		t.Errorf("%s holds %d entries, want only real and link", base, len(entries))
+	}
+}
+
+func TestRunRecordsReplacementsInManifest(t *testing.T) {
+	cfg := newTestConfig(t, "project")
+	cfg.Owner = "Ada Lovelace"
+	cfg.Values = map[string]string{"team": "Analytical Engines"}
+	projectPath := runInit(t, cfg)
+
+	recorded, err := manifest.Load(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"{{owner}}": "Ada Lovelace", "{{team}}": "Analytical Engines"}
+	if !maps.Equal(recorded.Replacements, want) {
+		t.Errorf("manifest replacements = %v, want %v", recorded.Replacements, want)
+	}
+}
+
+func TestRunHereCleanBefore(t *testing.T) {
+	projectPath := runInit(t, newTestConfig(t, "project"))
+	stale := filepath.Join(projectPath, ".claude", "commands", "plan.md")
+	t.Chdir(projectPath)
+
+	cfg := newTestConfig(t, "")
+	cfg.Here = true
+	cfg.CleanBefore = true
+	if _, err := Run(context.Background(), cfg, ui.NewQuietRenderer()); err == nil || !strings.Contains(err.Error(), "--clean-before needs --force") {
+		t.Fatalf("Run() error = %v, want the clean confirmation to be refused", err)
+	}
+	if _, err := os.Stat(stale); err != nil {
+		t.Errorf("managed file removed without confirmation: %v", err)
+	}
+
+	if err := os.WriteFile(stale, []byte("stale"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg = newTestConfig(t, "")
+	cfg.Here = true
+	cfg.CleanBefore = true
+	cfg.Force = true
+	if _, err := Run(context.Background(), cfg, ui.NewQuietRenderer()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if content, err := os.ReadFile(stale); err != nil || string(content) == "stale" {
+		t.Errorf("plan.md = %q, %v; want it re-created from the template", content, err)
+	}
+}
+
+func TestRunHereCleanBeforeWithoutManifest(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("notes.md", []byte("mine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := newTestConfig(t, "")
+	cfg.Here = true
+	cfg.CleanBefore = true
+	if _, err := Run(context.Background(), cfg, ui.NewQuietRenderer()); err == nil || !strings.Contains(err.Error(), "directory is not empty") {
+		t.Fatalf("Run() error = %v, want the non-empty directory to be refused", err)
+	}
+}
+
+func TestRunWithEditorConfig(t *testing.T) {
+	for key, want := range map[string]bool{"copilot": true, "windsurf": false, "claude": false} {
+		t.Run(key, func(t *testing.T) {
+			cfg := newTestConfig(t, "project")
+			cfg.AIAssistant = key
+			cfg.WithEditorConfig = true
+			projectPath := runInit(t, cfg)
+
+			_, err := os.Stat(filepath.Join(projectPath, ".vscode", "extensions.json"))
+			if written := err == nil; written != want {
+				t.Errorf("extensions.json written = %v, want %v", written, want)
+			}
+		})
+	}
+}
+
+func TestExpectedProjectFilesMatchManifest(t *testing.T) {
+	for _, keep := range []bool{false, true} {
+		t.Run(map[bool]string{false: "flattened", true: "keep-template-structure"}[keep], func(t *testing.T) {
+			cfg := newTestConfig(t, "project")
+			cfg.KeepTemplateStructure = keep
+			projectPath := runInit(t, cfg)
+
+			recorded, err := manifest.Load(projectPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if recorded.KeepTemplateStructure != keep {
+				t.Fatalf("manifest keep_template_structure = %v, want %v", recorded.KeepTemplateStructure, keep)
+			}
+			assets, err := LoadAssets(templates.EmbeddedSource{SetName: recorded.TemplateSet})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assistant := config.AIAssistants[recorded.AIAssistant]
+			expected, err := ExpectedProjectFiles(assets, &assistant, recorded.ScriptType, recorded.Replacements, nil, recorded.Commands, recorded.KeepTemplateStructure)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, entry := range recorded.Files {
+				content, exists := expected[entry.Path]
+				if !exists {
+					t.Errorf("%s is in the manifest but not expected", entry.Path)
+				} else if manifest.Hash(content) != entry.SHA256 {
+					t.Errorf("%s does not regenerate identically", entry.Path)
+				}
+			}
+			if len(expected) != len(recorded.Files) {
+				t.Errorf("expected %d files, manifest records %d", len(expected), len(recorded.Files))
+			}
+			if got := DetectScriptType(projectPath); got != recorded.ScriptType {
+				t.Errorf("DetectScriptType() = %q, want %q", got, recorded.ScriptType)
+			}
+		})
+	}
+}
+
+func TestRunHereProjectName(t *testing.T) {
+	for _, override := range []string{"", "Widget Docs"} {
+		t.Run(map[string]string{"": "detected", "Widget Docs": "overridden"}[override], func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			if err := os.WriteFile("package.json", []byte(`{"name": "@acme/widget"}`), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := newTestConfig(t, override)
+			cfg.Here = true
+			cfg.Force = true
+			result, err := Run(context.Background(), cfg, ui.NewQuietRenderer())
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			want := override
+			if want == "" {
+				want = config.DetectProjectName(result.Path)
+			}
+			if want == "" || result.Name != want {
+				t.Errorf("project name = %q, want %q", result.Name, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Package scaffold implements the project initialization shared by the CLI and pkg/initializer
+package scaffold
+
+import (
+	"log/slog"
+	"os"
+	"os/exec"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+)
+
+// newLogger creates the stderr logger for cfg, so logs never mix with
+// --output json on stdout. --debug lowers the level to debug regardless
+// of --log-level.
+func newLogger(cfg *config.ProjectConfig) *slog.Logger {
+	level := slog.LevelWarn
+	switch cfg.LogLevel {
+	case config.LogLevelDebug:
+		level = slog.LevelDebug
+	case config.LogLevelInfo:
+		level = slog.LevelInfo
+	case config.LogLevelError:
+		level = slog.LevelError
+	}
+	if cfg.Debug {
+		level = slog.LevelDebug
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+}
+
+// logger returns cfg's logger, or one that discards everything when the
+// configuration did not come through init
+func logger(cfg *config.ProjectConfig) *slog.Logger {
+	if cfg.Logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return cfg.Logger
+}
+
+// logStep logs each step status change at info level; sub-progress is left out
+func logStep(log *slog.Logger) func(config.Step) {
+	return func(step config.Step) {
+		if step.Status == config.StatusRunning && step.Current > 0 {
+			return
+		}
+		log.Info("step", "key", step.Key, "status", string(step.Status), "detail", step.Detail)
+	}
+}
+
+// gitCommand prepares a git command run in dir, logging its command line at debug level
+func gitCommand(log *slog.Logger, dir string, args ...string) *exec.Cmd {
+	log.Debug("running git", "dir", dir, "args", args)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	return cmd
+}
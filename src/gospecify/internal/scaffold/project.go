@@ -0,0 +1,229 @@
+// Package scaffold implements the project initialization shared by the CLI and pkg/initializer
+package scaffold
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/scripts"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/templates"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// assetSourceFor returns the template source selected by the configuration
+func assetSourceFor(ctx context.Context, cfg *config.ProjectConfig) templates.AssetSource {
+	if cfg.TemplateDir != "" {
+		return templates.DirSource{Root: filepath.Join(cfg.TemplateDir, templateDirAssets)}
+	}
+	if cfg.From != "" {
+		return templates.GitSource{URL: cfg.From, Ref: cfg.Ref, TempDir: cfg.TempDir, Proxy: cfg.Proxy, Logger: cfg.Logger, Context: ctx}
+	}
+	if cfg.TemplateRepo != "" || cfg.UseRelease {
+		return releaseSource{cfg: cfg, ctx: ctx}
+	}
+	return templates.EmbeddedSource{SetName: cfg.TemplateSet}
+}
+
+// LoadAssets loads the templates and scripts from source
+func LoadAssets(source templates.AssetSource) (*templates.EmbeddedAssets, error) {
+	assets, err := source.Load()
+	if err != nil {
+		return nil, errors.Wrap(errors.CodeOf(err), "failed to load assets from "+source.Describe(), err)
+	}
+	return assets, nil
+}
+
+// ExpectedProjectFiles returns every file init generates for the given
+// assistant and script type, keyed by slash-separated project-relative path.
+// agents lists every assistant sharing the project when there are several,
+// commands the commands installed for the assistant (empty means all), and
+// keepStructure selects the --keep-template-structure script layout.
+func ExpectedProjectFiles(assets *templates.EmbeddedAssets, assistant *config.AIAssistant, scriptType string, replacements map[string]string, agents, commands []string, keepStructure bool) (map[string][]byte, error) {
+	processedTemplates, err := templates.NewProcessor(assets, assistant, scriptType).
+		WithReplacements(replacements).
+		WithAgents(agents).
+		WithTemplateStructure(keepStructure).
+		ProcessAllTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	// Scripts are keyed by their path below .specify/scripts
+	generator := scripts.NewGenerator(assets, assistant, scriptType).
+		WithReplacements(replacements).
+		WithAgents(agents)
+	generatedScripts := make(map[string][]byte)
+	if keepStructure {
+		if generatedScripts, err = generator.GenerateScriptTree(); err != nil {
+			return nil, err
+		}
+	} else {
+		flatScripts, err := generator.GenerateAllScripts()
+		if err != nil {
+			return nil, err
+		}
+		for scriptName, content := range flatScripts {
+			generatedScripts[scriptName+scripts.GetScriptExtension(scriptType)] = content
+		}
+	}
+
+	selected, err := selectCommands(processedTemplates, commands)
+	if err != nil {
+		return nil, err
+	}
+	files := assistantCommandFiles(selected, assistant)
+	for templateName, content := range processedTemplates {
+		files[".specify/templates/"+templateName] = content
+	}
+	for scriptName, content := range generatedScripts {
+		files[".specify/scripts/"+scriptName] = content
+	}
+
+	return files, nil
+}
+
+// slashCommands returns the commands among assets selected by names (all of
+// them when names is empty) in name order, with their template descriptions
+func slashCommands(assets *templates.EmbeddedAssets, names []string) []config.SlashCommand {
+	var commands []config.SlashCommand
+	for templateName, content := range assets.Templates {
+		commandName, isCommand := strings.CutPrefix(templateName, "commands/")
+		if !isCommand {
+			continue
+		}
+		commandName = strings.TrimSuffix(commandName, path.Ext(commandName))
+		if len(names) > 0 && !slices.Contains(names, commandName) {
+			continue
+		}
+		commands = append(commands, config.SlashCommand{
+			Name:        commandName,
+			Description: templates.CommandDescription(templateName, content),
+		})
+	}
+	slices.SortFunc(commands, func(a, b config.SlashCommand) int { return strings.Compare(a.Name, b.Name) })
+	return commands
+}
+
+// selectCommands returns processedTemplates without the command templates
+// missing from names, matched on their base name (e.g. "plan" for
+// commands/plan.md). Every template is kept when names is empty.
+func selectCommands(processedTemplates map[string][]byte, names []string) (map[string][]byte, error) {
+	if len(names) == 0 {
+		return processedTemplates, nil
+	}
+
+	available := make(map[string]bool)
+	for templateName := range processedTemplates {
+		if commandName, isCommand := strings.CutPrefix(templateName, "commands/"); isCommand {
+			available[strings.TrimSuffix(commandName, path.Ext(commandName))] = true
+		}
+	}
+	var unknown []string
+	for _, name := range names {
+		if !available[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		known := make([]string, 0, len(available))
+		for name := range available {
+			known = append(known, name)
+		}
+		sort.Strings(known)
+		return nil, errors.NewValidationError(fmt.Sprintf("unknown command(s) for --commands: %s (available: %s)",
+			strings.Join(unknown, ", "), strings.Join(known, ", ")))
+	}
+
+	selected := make(map[string][]byte, len(processedTemplates))
+	for templateName, content := range processedTemplates {
+		if commandName, isCommand := strings.CutPrefix(templateName, "commands/"); isCommand &&
+			!slices.Contains(names, strings.TrimSuffix(commandName, path.Ext(commandName))) {
+			continue
+		}
+		selected[templateName] = content
+	}
+	return selected, nil
+}
+
+// commandMarker introduces each command in an aggregated command file
+const commandMarker = "<!-- gospecify:command %s -->"
+
+// assistantCommandFiles maps the processed command templates to the files
+// written into the assistant directory, keyed by project-relative path.
+// Assistants with a CommandFile get every command concatenated into that one
+// file in name order, each preceded by a commandMarker line.
+func assistantCommandFiles(processedTemplates map[string][]byte, assistant *config.AIAssistant) map[string][]byte {
+	commands := make(map[string][]byte)
+	for templateName, content := range processedTemplates {
+		if commandName, isCommand := strings.CutPrefix(templateName, "commands/"); isCommand {
+			commands[commandName] = content
+		}
+	}
+
+	files := make(map[string][]byte)
+	if assistant.CommandFile == "" {
+		for commandName, content := range commands {
+			files[path.Join(assistant.Directory, generateCommandFileName(commandName, assistant))] = content
+		}
+		return files
+	}
+	if len(commands) == 0 {
+		return files
+	}
+
+	names := make([]string, 0, len(commands))
+	for commandName := range commands {
+		names = append(names, commandName)
+	}
+	sort.Strings(names)
+
+	var aggregated bytes.Buffer
+	fmt.Fprintf(&aggregated, "# %s commands\n", assistant.Name)
+	for _, commandName := range names {
+		aggregated.WriteString("\n")
+		fmt.Fprintf(&aggregated, commandMarker+"\n\n", strings.TrimSuffix(commandName, path.Ext(commandName)))
+		aggregated.Write(bytes.TrimRight(commands[commandName], "\n"))
+		aggregated.WriteString("\n")
+	}
+	files[path.Join(assistant.Directory, assistant.CommandFile)] = aggregated.Bytes()
+	return files
+}
+
+// DetectScriptType infers the script type of an existing project from its generated scripts
+func DetectScriptType(projectPath string) string {
+	detected := ""
+	// Scripts sit one level deeper with --keep-template-structure
+	_ = filepath.WalkDir(filepath.Join(projectPath, ".specify", "scripts"), func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		for key := range config.ScriptTypes {
+			if filepath.Ext(entry.Name()) == scripts.GetScriptExtension(key) {
+				detected = key
+				return filepath.SkipAll
+			}
+		}
+		return nil
+	})
+	return detected
+}
+
+// SharedAgents returns the sorted keys when several assistants share the
+// project, so __AGENT__ names all of them the same way whichever command
+// regenerates the files, and nil for a single assistant
+func SharedAgents(keys []string) []string {
+	if len(keys) < 2 {
+		return nil
+	}
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+	return sorted
+}
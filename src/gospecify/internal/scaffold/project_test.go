@@ -0,0 +1,116 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/templates"
+)
+
+// processedCommands is a processed template set with two commands and a
+// non-command template
+var processedCommands = map[string][]byte{
+	"commands/tasks.md": []byte("Break down the work.\n"),
+	"commands/plan.md":  []byte("Plan the work.\n\n"),
+	"spec-template.md":  []byte("# Spec\n"),
+}
+
+func TestAssistantCommandFilesPerCommand(t *testing.T) {
+	for key, want := range map[string][]string{
+		"claude":  {".claude/commands/plan.md", ".claude/commands/tasks.md"},
+		"gemini":  {".gemini/commands/plan.toml", ".gemini/commands/tasks.toml"},
+		"copilot": {".github/prompts/plan.prompt.md", ".github/prompts/tasks.prompt.md"},
+	} {
+		t.Run(key, func(t *testing.T) {
+			assistant := config.AIAssistants[key]
+			files := assistantCommandFiles(processedCommands, &assistant)
+
+			if len(files) != len(want) {
+				t.Fatalf("got %d files, want %v", len(files), want)
+			}
+			for _, relPath := range want {
+				if _, exists := files[relPath]; !exists {
+					t.Errorf("missing %s in %v", relPath, files)
+				}
+			}
+			if got := string(files[want[0]]); got != string(processedCommands["commands/plan.md"]) {
+				t.Errorf("%s = %q, want the processed template unchanged", want[0], got)
+			}
+		})
+	}
+}
+
+func TestAssistantCommandFilesAggregated(t *testing.T) {
+	assistant := config.AIAssistants["codex"]
+	if assistant.CommandFile == "" {
+		t.Fatal("codex no longer aggregates its commands")
+	}
+	files := assistantCommandFiles(processedCommands, &assistant)
+
+	if len(files) != 1 {
+		t.Fatalf("got %d files, want only %s", len(files), assistant.CommandFile)
+	}
+	content, exists := files[".codex/commands.md"]
+	if !exists {
+		t.Fatalf("files = %v, want .codex/commands.md", files)
+	}
+	want := "# Codex CLI commands\n" +
+		"\n<!-- gospecify:command plan -->\n\nPlan the work.\n" +
+		"\n<!-- gospecify:command tasks -->\n\nBreak down the work.\n"
+	if string(content) != want {
+		t.Errorf("commands.md =\n%s\nwant\n%s", content, want)
+	}
+}
+
+func TestAssistantCommandFilesAggregatedEmpty(t *testing.T) {
+	assistant := config.AIAssistants["codex"]
+	files := assistantCommandFiles(map[string][]byte{"spec-template.md": []byte("# Spec\n")}, &assistant)
+	if len(files) != 0 {
+		t.Errorf("files = %v, want none without commands", files)
+	}
+}
+
+func TestRunWritesAggregatedCommandFile(t *testing.T) {
+	cfg := newTestConfig(t, "project")
+	cfg.AIAssistant = "codex"
+	projectPath := runInit(t, cfg)
+
+	content, err := os.ReadFile(filepath.Join(projectPath, ".codex", "commands.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, command := range []string{"plan", "specify", "tasks"} {
+		if !strings.Contains(string(content), fmt.Sprintf(commandMarker, command)) {
+			t.Errorf("commands.md lacks the %s command", command)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, ".codex", "plan.md")); !os.IsNotExist(err) {
+		t.Errorf("plan.md was also written on its own (stat error = %v)", err)
+	}
+}
+
+func TestSlashCommands(t *testing.T) {
+	assets := &templates.EmbeddedAssets{Templates: map[string][]byte{
+		"commands/tasks.md":  []byte("---\ndescription: Break down the work.\n---\n\nBody\n"),
+		"commands/plan.toml": []byte("description = \"Plan the work.\"\nprompt = \"Plan\"\n"),
+		"commands/notes.md":  []byte("No front matter\n"),
+		"spec-template.md":   []byte("---\ndescription: Not a command\n---\n"),
+	}}
+
+	want := []config.SlashCommand{
+		{Name: "notes"},
+		{Name: "plan", Description: "Plan the work."},
+		{Name: "tasks", Description: "Break down the work."},
+	}
+	if got := slashCommands(assets, nil); !slices.Equal(got, want) {
+		t.Errorf("slashCommands() = %v, want %v", got, want)
+	}
+	if got := slashCommands(assets, []string{"tasks"}); !slices.Equal(got, want[2:]) {
+		t.Errorf("slashCommands(tasks) = %v, want %v", got, want[2:])
+	}
+}
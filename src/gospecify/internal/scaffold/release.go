@@ -0,0 +1,148 @@
+// Package scaffold implements the project initialization shared by the CLI and pkg/initializer
+package scaffold
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/github"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/templates"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/ui"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// releaseSource downloads the template archive for an assistant from a
+// GitHub release and loads the templates/ and scripts/ it contains
+type releaseSource struct {
+	cfg *config.ProjectConfig
+	// ctx aborts the download when init is interrupted or times out
+	ctx context.Context
+	// progress, when set, shows how far the archive download has got
+	progress *ui.DownloadProgress
+}
+
+// downloadAttempts bounds how often an interrupted release download is resumed
+const downloadAttempts = 3
+
+// Load implements templates.AssetSource. A release without an archive for the
+// assistant yields an AssetNotFound error so callers can fall back to the
+// embedded templates.
+func (s releaseSource) Load() (*templates.EmbeddedAssets, error) {
+	owner, repo, _ := strings.Cut(s.repository(), "/")
+	client := newGitHubClient(github.GetGitHubToken(s.cfg.GitHubToken, s.cfg.GitHubAPIURL), s.cfg)
+	ctx := s.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// An unreachable GitHub fails fast with a diagnosis instead of after retries
+	if err := client.Preflight(ctx); err != nil {
+		return nil, err
+	}
+	release, err := client.GetRelease(ctx, owner, repo, s.cfg.TemplateRef)
+	if err != nil {
+		return nil, err
+	}
+	asset, err := github.FindTemplateAsset(release, s.cfg.AIAssistant, s.cfg.ScriptType)
+	if err != nil {
+		return nil, err
+	}
+
+	tempDir, err := os.MkdirTemp(s.cfg.TempDir, "gospecify-release-*")
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeFileSystemError, "failed to create temporary directory", err)
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	checksum, _, err := client.ReleaseChecksum(ctx, release, *asset)
+	if err != nil {
+		return nil, err
+	}
+	zipPath := filepath.Join(tempDir, asset.Name)
+	var progressFn func(int64, int64)
+	if s.progress != nil {
+		progressFn = s.progress.Update
+	}
+	// Each retry resumes from the bytes the interrupted attempt left in zipPath
+	for attempt := 1; ; attempt++ {
+		err = client.DownloadAsset(ctx, *asset, zipPath, checksum, progressFn)
+		if err == nil || errors.CodeOf(err) != errors.ErrCodeNetworkError || attempt == downloadAttempts || ctx.Err() != nil {
+			break
+		}
+	}
+	if s.progress != nil {
+		s.progress.Finish()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	extractDir := filepath.Join(tempDir, "extracted")
+	if err := github.NewExtractor(extractDir).WithLogger(s.cfg.Logger).WithSymlinks(s.cfg.AllowSymlinks).ExtractZip(zipPath, nil); err != nil {
+		return nil, err
+	}
+
+	root, ok := releaseAssetRoot(extractDir)
+	if !ok {
+		return nil, errors.NewTemplateError(
+			fmt.Sprintf("%s contains no templates directory", asset.Name), nil)
+	}
+	return templates.DirSource{Root: root}.Load()
+}
+
+// Describe implements templates.AssetSource
+func (s releaseSource) Describe() string {
+	return fmt.Sprintf("release %s of %s", github.ReleaseName(s.cfg.TemplateRef), s.repository())
+}
+
+// repository returns the owner/name the release is read from: --template-repo,
+// or the upstream spec-kit repository for --use-release
+func (s releaseSource) repository() string {
+	if s.cfg.TemplateRepo != "" {
+		return s.cfg.TemplateRepo
+	}
+	return config.GitHubOwner + "/" + config.GitHubRepo
+}
+
+// releaseUnavailable reports whether a release download failed in a way
+// that the embedded templates can stand in for: no network, a GitHub error
+// such as rate limiting, or no archive for the assistant
+func releaseUnavailable(err error) bool {
+	switch errors.CodeOf(err) {
+	case errors.ErrCodeNetworkError, errors.ErrCodeGitHubAPIError, errors.ErrCodeAssetNotFound:
+		return true
+	}
+	return false
+}
+
+// releaseAssetRoot finds the directory holding templates/ in an extracted
+// archive: its root, its .specify directory (the layout of spec-kit release
+// archives) or a single top-level directory wrapping either
+func releaseAssetRoot(dir string) (string, bool) {
+	for _, candidate := range []string{dir, filepath.Join(dir, ".specify")} {
+		if info, err := os.Stat(filepath.Join(candidate, "templates")); err == nil && info.IsDir() {
+			return candidate, true
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 || !entries[0].IsDir() || entries[0].Name() == ".specify" {
+		return "", false
+	}
+	return releaseAssetRoot(filepath.Join(dir, entries[0].Name()))
+}
+
+// validateTemplateRepo checks that repo has the owner/name form
+func validateTemplateRepo(repo string) error {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") || !fs.ValidPath(repo) {
+		return errors.NewValidationError(
+			fmt.Sprintf("invalid --template-repo %q: expected owner/name", repo))
+	}
+	return nil
+}
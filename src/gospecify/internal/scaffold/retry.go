@@ -0,0 +1,158 @@
+// Package scaffold implements the project initialization shared by the CLI and pkg/initializer
+package scaffold
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// initStatePath is the project-relative location of the state left by a failed init
+const initStatePath = ".specify/init-state.json"
+
+// initState records how far a failed init got so it can be resumed with --retry-step
+type initState struct {
+	AIAssistant string   `json:"ai_assistant"`
+	ScriptType  string   `json:"script_type"`
+	TemplateSet string   `json:"template_set"`
+	Completed   []string `json:"completed"`
+	Failed      string   `json:"failed,omitempty"`
+}
+
+// saveInitState persists the tracker's completed and failed steps into the project
+func saveInitState(cfg *config.ProjectConfig, tracker *config.StepTracker) error {
+	state := initState{
+		AIAssistant: cfg.AIAssistant,
+		ScriptType:  cfg.ScriptType,
+		TemplateSet: cfg.TemplateSet,
+		Completed:   []string{},
+	}
+	for _, step := range tracker.GetSteps() {
+		switch step.Status {
+		case config.StatusDone, config.StatusSkipped:
+			state.Completed = append(state.Completed, step.Key)
+		case config.StatusError:
+			state.Failed = step.Key
+		}
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to encode init state", err)
+	}
+
+	statePath := filepath.Join(cfg.Path, filepath.FromSlash(initStatePath))
+	if err := os.MkdirAll(filepath.Dir(statePath), 0755); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to create state directory", err)
+	}
+	if err := os.WriteFile(statePath, append(data, '\n'), 0644); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to write init state", err)
+	}
+	return nil
+}
+
+// loadInitState reads the state left by a failed init
+func loadInitState(projectPath string) (*initState, error) {
+	statePath := filepath.Join(projectPath, filepath.FromSlash(initStatePath))
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.NewValidationError(
+				fmt.Sprintf("no failed init to retry in %s (%s not found)", projectPath, initStatePath))
+		}
+		return nil, errors.Wrap(errors.ErrCodeFileSystemError, "failed to read init state", err)
+	}
+
+	var state initState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, errors.Wrap(errors.ErrCodeInvalidConfig, "failed to parse init state", err)
+	}
+
+	return &state, nil
+}
+
+// prepareRetry validates the --retry-step key against the tracker and the
+// persisted state, and restores the settings the failed run used. The state
+// file is removed once accepted, so a successful retry leaves nothing behind
+// and a failed one writes a fresh copy.
+func prepareRetry(cfg *config.ProjectConfig, tracker *config.StepTracker) error {
+	var keys []string
+	found := false
+	for _, step := range tracker.GetSteps() {
+		if step.Key == cfg.RetryStep {
+			found = true
+			break
+		}
+		keys = append(keys, step.Key)
+	}
+	if !found {
+		var known []string
+		for _, step := range tracker.GetSteps() {
+			known = append(known, step.Key)
+		}
+		return errors.NewValidationError(
+			fmt.Sprintf("unknown step %q for --retry-step (available: %s)", cfg.RetryStep, strings.Join(known, ", ")))
+	}
+
+	state, err := loadInitState(cfg.Path)
+	if err != nil {
+		return err
+	}
+
+	completed := make(map[string]bool, len(state.Completed))
+	for _, key := range state.Completed {
+		completed[key] = true
+	}
+	for _, key := range keys {
+		if !completed[key] {
+			return errors.NewValidationError(
+				fmt.Sprintf("cannot retry step %q: prerequisite step %q did not complete", cfg.RetryStep, key))
+		}
+	}
+
+	restore := func(flag string, target *string, value string) error {
+		if *target != "" && value != "" && *target != value {
+			return errors.NewValidationError(
+				fmt.Sprintf("--%s %s does not match the failed run (%s)", flag, *target, value))
+		}
+		if value != "" {
+			*target = value
+		}
+		return nil
+	}
+	if err := restore("ai", &cfg.AIAssistant, state.AIAssistant); err != nil {
+		return err
+	}
+	if err := restore("script", &cfg.ScriptType, state.ScriptType); err != nil {
+		return err
+	}
+	if state.TemplateSet != "" {
+		cfg.TemplateSet = state.TemplateSet
+	}
+
+	if err := os.Remove(filepath.Join(cfg.Path, filepath.FromSlash(initStatePath))); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to remove init state", err)
+	}
+	return nil
+}
+
+// resumedStep reports whether key ran to completion before the --retry-step point
+func resumedStep(cfg *config.ProjectConfig, tracker *config.StepTracker, key string) bool {
+	if cfg.RetryStep == "" {
+		return false
+	}
+	for _, step := range tracker.GetSteps() {
+		switch step.Key {
+		case cfg.RetryStep:
+			return false
+		case key:
+			return true
+		}
+	}
+	return false
+}
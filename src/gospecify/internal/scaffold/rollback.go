@@ -0,0 +1,79 @@
+// Package scaffold implements the project initialization shared by the CLI and pkg/initializer
+package scaffold
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// rollbackJournal records the files and directories an init run creates
+// below the project root, so --rollback-on-error can remove exactly those.
+// A nil journal records nothing.
+type rollbackJournal struct {
+	root    string
+	created []string
+	seen    map[string]bool
+	mu      sync.Mutex
+}
+
+// newRollbackJournal creates a journal for the project at root
+func newRollbackJournal(root string) *rollbackJournal {
+	return &rollbackJournal{
+		root: root,
+		seen: make(map[string]bool),
+	}
+}
+
+// track records relPath, and each of its parent directories, that does not
+// exist yet; call it before creating relPath
+func (j *rollbackJournal) track(relPath string) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	parts := strings.Split(path.Clean(filepath.ToSlash(relPath)), "/")
+	for i := range parts {
+		current := path.Join(parts[:i+1]...)
+		if current == "." || j.seen[current] {
+			continue
+		}
+		j.seen[current] = true
+		if _, err := os.Lstat(filepath.Join(j.root, filepath.FromSlash(current))); os.IsNotExist(err) {
+			j.created = append(j.created, current)
+		}
+	}
+}
+
+// rollback removes everything recorded, newest first, and returns how many
+// paths were removed and the first error met
+func (j *rollbackJournal) rollback() (int, error) {
+	if j == nil {
+		return 0, nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	removed := 0
+	var firstErr error
+	for i := len(j.created) - 1; i >= 0; i-- {
+		fullPath := filepath.Join(j.root, filepath.FromSlash(j.created[i]))
+		if _, err := os.Lstat(fullPath); err != nil {
+			continue
+		}
+		// Directories created by this run hold nothing that existed before it
+		if err := os.RemoveAll(fullPath); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		removed++
+	}
+	j.created = nil
+	return removed, firstErr
+}
@@ -0,0 +1,251 @@
+// Package scaffold implements the project initialization shared by the CLI and pkg/initializer
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/manifest"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// ProjectWriter writes generated files below a project root and records
+// each one in the project manifest. In dry-run mode nothing is written and
+// the intended operations are collected instead.
+type ProjectWriter struct {
+	root        string
+	manifest    *manifest.Manifest
+	executables map[string]bool
+	dryRun      bool
+	planned     []string
+	plannedDirs map[string]bool
+	journal     *rollbackJournal
+	logger      *slog.Logger
+	// keepShared leaves existing .specify/ files alone, collecting those
+	// that differ from both the generated content and the manifest in kept
+	keepShared bool
+	kept       []string
+	mu         sync.Mutex
+}
+
+// pendingFile is a file queued for writeAll
+type pendingFile struct {
+	relPath string
+	content []byte
+	perm    os.FileMode
+}
+
+// NewProjectWriter creates a writer rooted at projectPath
+func NewProjectWriter(projectPath string, m *manifest.Manifest) *ProjectWriter {
+	return &ProjectWriter{
+		root:        projectPath,
+		manifest:    m,
+		executables: make(map[string]bool),
+		plannedDirs: make(map[string]bool),
+		logger:      slog.New(slog.DiscardHandler),
+	}
+}
+
+// mkdirAll creates a project-relative directory and its parents
+func (w *ProjectWriter) mkdirAll(relPath string) error {
+	if w.dryRun {
+		dir := path.Clean(filepath.ToSlash(relPath))
+		if _, err := os.Stat(filepath.Join(w.root, filepath.FromSlash(dir))); err == nil {
+			return nil
+		}
+		w.mu.Lock()
+		seen := w.plannedDirs[dir]
+		w.plannedDirs[dir] = true
+		w.mu.Unlock()
+		if !seen {
+			w.plan("create directory " + dir)
+		}
+		return nil
+	}
+	w.journal.track(relPath)
+	if err := os.MkdirAll(filepath.Join(w.root, filepath.FromSlash(relPath)), 0755); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to create directory", err)
+	}
+	return nil
+}
+
+// WriteFile writes a project-relative file, creating parent directories as needed
+func (w *ProjectWriter) WriteFile(relPath string, content []byte, perm os.FileMode) error {
+	fullPath := filepath.Join(w.root, filepath.FromSlash(relPath))
+	if w.keepShared && strings.HasPrefix(filepath.ToSlash(relPath), ".specify/") {
+		if onDisk, err := os.ReadFile(fullPath); err == nil {
+			w.mu.Lock()
+			// A file matching its manifest entry is simply another assistant's version
+			entry, managed := w.manifest.Lookup(relPath)
+			switch {
+			case bytes.Equal(onDisk, content):
+				w.manifest.Add(relPath, content)
+			case !managed || entry.SHA256 != manifest.Hash(onDisk):
+				w.kept = append(w.kept, filepath.ToSlash(relPath))
+			}
+			w.mu.Unlock()
+			return nil
+		}
+	}
+	if w.dryRun {
+		action := "write"
+		if _, err := os.Stat(fullPath); err == nil {
+			action = "overwrite"
+		}
+		w.plan(fmt.Sprintf("%s %s (%d bytes, mode %04o)", action, filepath.ToSlash(relPath), len(content), perm))
+		w.mu.Lock()
+		w.manifest.Add(relPath, content)
+		w.mu.Unlock()
+		return nil
+	}
+
+	w.journal.track(relPath)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to create directory", err)
+	}
+
+	w.logger.Debug("writing file", "path", fullPath, "bytes", len(content), "mode", fmt.Sprintf("%04o", perm))
+	if err := os.WriteFile(fullPath, content, perm); err != nil {
+		return errors.Wrap(errors.ErrCodeFileSystemError, "failed to write "+filepath.ToSlash(relPath), err)
+	}
+
+	w.mu.Lock()
+	w.manifest.Add(relPath, content)
+	if perm&0111 != 0 {
+		w.executables[filepath.ToSlash(relPath)] = true
+	}
+	w.mu.Unlock()
+	return nil
+}
+
+// saveManifest writes the manifest into the project unless this is a dry run
+func (w *ProjectWriter) saveManifest() error {
+	if w.dryRun {
+		w.plan("write " + manifest.RelativePath)
+		return nil
+	}
+	w.journal.track(manifest.RelativePath)
+	return w.manifest.Save(w.root)
+}
+
+// plan records an operation a dry run would have performed
+func (w *ProjectWriter) plan(operation string) {
+	w.mu.Lock()
+	w.planned = append(w.planned, operation)
+	w.mu.Unlock()
+}
+
+// takePlanned returns the sorted operations planned since the last call
+func (w *ProjectWriter) takePlanned() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	planned := w.planned
+	w.planned = nil
+	sort.Strings(planned)
+	return planned
+}
+
+// takeKept returns the sorted shared files kept despite local changes since the last call
+func (w *ProjectWriter) takeKept() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	kept := w.kept
+	w.kept = nil
+	sort.Strings(kept)
+	return kept
+}
+
+// verify re-reads every file recorded in the manifest and returns a sorted
+// description of each one that is missing, differs from its recorded hash,
+// or lost the executable bit it was written with
+func (w *ProjectWriter) verify() []string {
+	var problems []string
+	for _, relPath := range w.manifest.Paths() {
+		fullPath := filepath.Join(w.root, filepath.FromSlash(relPath))
+		content, err := os.ReadFile(fullPath)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", relPath, err))
+			continue
+		}
+
+		entry, _ := w.manifest.Lookup(relPath)
+		if manifest.Hash(content) != entry.SHA256 {
+			problems = append(problems, relPath+": content does not match what was written")
+			continue
+		}
+
+		// Windows has no executable bit to check
+		if w.executables[relPath] && runtime.GOOS != "windows" {
+			if info, err := os.Stat(fullPath); err == nil && info.Mode().Perm()&0111 == 0 {
+				problems = append(problems, relPath+": not executable")
+			}
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// writeAll writes files using up to concurrency workers, calling done after
+// each file. Parent directories are created first, in sorted order so parents
+// precede children, and the workers only write file contents. If several writes
+// fail, the error for the first path in sorted order is returned.
+func (w *ProjectWriter) writeAll(files []pendingFile, concurrency int, done func()) error {
+	sort.Slice(files, func(i, j int) bool { return files[i].relPath < files[j].relPath })
+
+	dirSet := make(map[string]bool)
+	for _, file := range files {
+		dirSet[path.Dir(filepath.ToSlash(file.relPath))] = true
+	}
+	dirs := make([]string, 0, len(dirSet))
+	for dir := range dirSet {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		if err := w.mkdirAll(dir); err != nil {
+			return err
+		}
+	}
+
+	concurrency = max(1, min(concurrency, len(files)))
+	errs := make([]error, len(files))
+	jobs := make(chan int)
+	var doneMu sync.Mutex
+	var wg sync.WaitGroup
+
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = w.WriteFile(files[i].relPath, files[i].content, files[i].perm)
+				if errs[i] == nil && done != nil {
+					doneMu.Lock()
+					done()
+					doneMu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
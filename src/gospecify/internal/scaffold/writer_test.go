@@ -0,0 +1,69 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/manifest"
+)
+
+// pendingFiles returns n files of size bytes spread across nested directories,
+// like a large template set
+func pendingFiles(n, size int) []pendingFile {
+	content := make([]byte, size)
+	for i := range content {
+		content[i] = 'a' + byte(i%26)
+	}
+	files := make([]pendingFile, n)
+	for i := range files {
+		files[i] = pendingFile{
+			relPath: fmt.Sprintf(".specify/templates/group-%02d/nested/template-%03d.md", i%10, i),
+			content: content,
+			perm:    0644,
+		}
+	}
+	return files
+}
+
+func TestWriteAll(t *testing.T) {
+	for _, concurrency := range []int{1, 8} {
+		t.Run(fmt.Sprintf("concurrency-%d", concurrency), func(t *testing.T) {
+			root := t.TempDir()
+			writer := NewProjectWriter(root, manifest.New(&config.ProjectConfig{}))
+			files := pendingFiles(50, 128)
+
+			written := 0
+			if err := writer.writeAll(files, concurrency, func() { written++ }); err != nil {
+				t.Fatalf("writeAll() error = %v", err)
+			}
+			if written != len(files) {
+				t.Errorf("done called %d times, want %d", written, len(files))
+			}
+			for _, file := range files {
+				if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(file.relPath))); err != nil {
+					t.Errorf("%s was not written: %v", file.relPath, err)
+				}
+			}
+			if got := len(writer.manifest.Paths()); got != len(files) {
+				t.Errorf("manifest records %d files, want %d", got, len(files))
+			}
+		})
+	}
+}
+
+func BenchmarkWriteAll(b *testing.B) {
+	files := pendingFiles(200, 4096)
+	for _, concurrency := range []int{1, config.DefaultWriteConcurrency, 16} {
+		b.Run(fmt.Sprintf("concurrency-%d", concurrency), func(b *testing.B) {
+			for b.Loop() {
+				writer := NewProjectWriter(b.TempDir(), manifest.New(&config.ProjectConfig{}))
+				if err := writer.writeAll(files, concurrency, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -2,10 +2,13 @@
 package scripts
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
 	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
@@ -15,6 +18,7 @@ import (
 type Executor struct {
 	projectPath string
 	scriptType  string
+	generator   *Generator
 }
 
 // NewExecutor creates a new script executor
@@ -25,6 +29,13 @@ func NewExecutor(projectPath, scriptType string) *Executor {
 	}
 }
 
+// WithGenerator sets the generator used to render scripts from the embedded
+// assets when the project has no generated copy
+func (e *Executor) WithGenerator(generator *Generator) *Executor {
+	e.generator = generator
+	return e
+}
+
 // ExecuteScript executes a script by name with optional arguments
 func (e *Executor) ExecuteScript(scriptName string, args ...string) error {
 	script, err := e.getScriptContent(scriptName)
@@ -43,22 +54,44 @@ func (e *Executor) ExecuteScript(scriptName string, args ...string) error {
 	return e.executeScriptFile(tempFile, args...)
 }
 
-// getScriptContent retrieves script content from embedded assets
+// getScriptContent returns the project's generated copy of a script from
+// .specify/scripts, or renders it from the embedded assets
 func (e *Executor) getScriptContent(scriptName string) ([]byte, error) {
-	// This will be implemented when we integrate with the embedded assets
-	// For now, return a placeholder error
-	return nil, errors.NewAssetNotFound(fmt.Sprintf("script %s", scriptName))
+	extension := GetScriptExtension(e.scriptType)
+	if extension == "" {
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported script type: %s", e.scriptType))
+	}
+
+	// Flat layout first, then the one kept by --keep-template-structure
+	scriptsDir := filepath.Join(e.projectPath, ".specify", "scripts")
+	candidates := []string{filepath.Join(scriptsDir, scriptName+extension)}
+	if e.generator != nil {
+		candidates = append(candidates, filepath.Join(scriptsDir, filepath.FromSlash(e.generator.getScriptPath(scriptName))))
+	}
+	for _, candidate := range candidates {
+		content, err := os.ReadFile(candidate)
+		if err == nil {
+			return content, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, errors.Wrap(errors.ErrCodeFileSystemError, fmt.Sprintf("failed to read script %s", candidate), err)
+		}
+	}
+
+	if e.generator == nil {
+		return nil, errors.NewAssetNotFound(fmt.Sprintf("script %s%s in %s", scriptName, extension, scriptsDir))
+	}
+	content, err := e.generator.GenerateScript(scriptName)
+	if err != nil {
+		return nil, errors.NewAssetNotFound(fmt.Sprintf("script %s for script type %s", scriptName, e.scriptType))
+	}
+	return content, nil
 }
 
 // createTempScript creates a temporary script file with proper permissions
 func (e *Executor) createTempScript(content []byte) (string, error) {
-	var extension string
-	switch e.scriptType {
-	case config.ScriptTypeBash:
-		extension = ".sh"
-	case config.ScriptTypePowerShell:
-		extension = ".ps1"
-	default:
+	extension := GetScriptExtension(e.scriptType)
+	if extension == "" {
 		return "", errors.NewValidationError(fmt.Sprintf("unsupported script type: %s", e.scriptType))
 	}
 
@@ -74,7 +107,7 @@ func (e *Executor) createTempScript(content []byte) (string, error) {
 	}
 
 	// Make executable on Unix systems
-	if e.scriptType == config.ScriptTypeBash && runtime.GOOS != "windows" {
+	if e.scriptType != config.ScriptTypePowerShell && runtime.GOOS != "windows" {
 		if err := os.Chmod(tempFile.Name(), 0755); err != nil {
 			_ = os.Remove(tempFile.Name())
 			return "", errors.Wrap(errors.ErrCodeFileSystemError, "failed to make script executable", err)
@@ -93,6 +126,10 @@ func (e *Executor) executeScriptFile(scriptPath string, args ...string) error {
 		cmd = e.createBashCommand(scriptPath, args...)
 	case config.ScriptTypePowerShell:
 		cmd = e.createPowerShellCommand(scriptPath, args...)
+	case config.ScriptTypeFish:
+		cmd = exec.Command("fish", append([]string{scriptPath}, args...)...)
+	case config.ScriptTypeNushell:
+		cmd = exec.Command("nu", append([]string{scriptPath}, args...)...)
 	default:
 		return errors.NewValidationError(fmt.Sprintf("unsupported script type: %s", e.scriptType))
 	}
@@ -103,8 +140,17 @@ func (e *Executor) executeScriptFile(scriptPath string, args ...string) error {
 	// Set environment
 	cmd.Env = os.Environ()
 
+	// Capture stderr so policy failures can be told apart from script errors
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
 	// Execute command
 	if err := cmd.Run(); err != nil {
+		if e.scriptType == config.ScriptTypePowerShell && isExecutionPolicyError(stderr.String()) {
+			return errors.NewScriptError(
+				"PowerShell execution policy blocked the script; a Group Policy may be overriding -ExecutionPolicy Bypass. "+
+					"Check 'Get-ExecutionPolicy -List' or ask your administrator to allow local scripts", err)
+		}
 		return errors.Wrap(errors.ErrCodeScriptError,
 			fmt.Sprintf("script execution failed: %s", scriptPath), err)
 	}
@@ -112,6 +158,26 @@ func (e *Executor) executeScriptFile(scriptPath string, args ...string) error {
 	return nil
 }
 
+// executionPolicyMarkers are fragments of the errors PowerShell prints when its
+// execution policy refuses to run a script
+var executionPolicyMarkers = []string{
+	"running scripts is disabled on this system",
+	"is not digitally signed",
+	"about_execution_policies",
+	"unauthorizedaccess",
+}
+
+// isExecutionPolicyError reports whether PowerShell output indicates an execution policy failure
+func isExecutionPolicyError(output string) bool {
+	output = strings.ToLower(output)
+	for _, marker := range executionPolicyMarkers {
+		if strings.Contains(output, marker) {
+			return true
+		}
+	}
+	return false
+}
+
 // createBashCommand creates a bash command for script execution
 func (e *Executor) createBashCommand(scriptPath string, args ...string) *exec.Cmd {
 	if runtime.GOOS == "windows" {
@@ -135,9 +201,9 @@ func (e *Executor) createPowerShellCommand(scriptPath string, args ...string) *e
 			append([]string{"-ExecutionPolicy", "Bypass", "-File", scriptPath}, args...)...)
 	}
 
-	// Unix-like systems with PowerShell Core (pwsh)
+	// Unix-like systems with PowerShell Core (pwsh), which also enforces policies
 	return exec.Command("pwsh",
-		append([]string{"-File", scriptPath}, args...)...)
+		append([]string{"-ExecutionPolicy", "Bypass", "-File", scriptPath}, args...)...)
 }
 
 // findBashOnWindows finds bash executable on Windows systems
@@ -167,7 +233,7 @@ func (e *Executor) findBashOnWindows() string {
 // ValidateScriptType validates that the script type is supported
 func ValidateScriptType(scriptType string) error {
 	switch scriptType {
-	case config.ScriptTypeBash, config.ScriptTypePowerShell:
+	case config.ScriptTypeBash, config.ScriptTypePowerShell, config.ScriptTypeFish, config.ScriptTypeNushell:
 		return nil
 	default:
 		return errors.NewValidationError(fmt.Sprintf("unsupported script type: %s", scriptType))
@@ -181,6 +247,10 @@ func GetScriptExtension(scriptType string) string {
 		return ".sh"
 	case config.ScriptTypePowerShell:
 		return ".ps1"
+	case config.ScriptTypeFish:
+		return ".fish"
+	case config.ScriptTypeNushell:
+		return ".nu"
 	default:
 		return ""
 	}
@@ -3,6 +3,7 @@ package scripts
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
@@ -12,9 +13,11 @@ import (
 
 // Generator creates dynamic scripts from embedded templates
 type Generator struct {
-	assets     *templates.EmbeddedAssets
-	assistant  *config.AIAssistant
-	scriptType string
+	assets       *templates.EmbeddedAssets
+	assistant    *config.AIAssistant
+	scriptType   string
+	replacements map[string]string
+	agents       []string
 }
 
 // NewGenerator creates a new script generator
@@ -26,6 +29,24 @@ func NewGenerator(assets *templates.EmbeddedAssets, assistant *config.AIAssistan
 	}
 }
 
+// WithReplacements adds placeholder values applied after the built-in replacements
+func (g *Generator) WithReplacements(replacements map[string]string) *Generator {
+	if g.replacements == nil {
+		g.replacements = make(map[string]string, len(replacements))
+	}
+	for placeholder, value := range replacements {
+		g.replacements[placeholder] = value
+	}
+	return g
+}
+
+// WithAgents sets the assistants sharing the project; __AGENT__ then expands
+// to their comma-separated keys
+func (g *Generator) WithAgents(keys []string) *Generator {
+	g.agents = keys
+	return g
+}
+
 // GenerateScript generates a script from an embedded template
 func (g *Generator) GenerateScript(scriptName string) ([]byte, error) {
 	script, exists := g.assets.GetScript(g.getScriptPath(scriptName))
@@ -51,6 +72,12 @@ func (g *Generator) getScriptPath(scriptName string) string {
 	case config.ScriptTypePowerShell:
 		directory = "powershell"
 		extension = ".ps1"
+	case config.ScriptTypeFish:
+		directory = "fish"
+		extension = ".fish"
+	case config.ScriptTypeNushell:
+		directory = "nu"
+		extension = ".nu"
 	default:
 		directory = "bash"
 		extension = ".sh"
@@ -62,17 +89,28 @@ func (g *Generator) getScriptPath(scriptName string) string {
 // applyReplacements applies placeholder replacements to script content
 func (g *Generator) applyReplacements(content string) string {
 	replacements := map[string]string{
-		"__AGENT__": g.assistant.Key,
+		"__AGENT__": g.agentReference(),
 		"{SCRIPT}":  g.getScriptReference(),
 	}
 
 	for placeholder, replacement := range replacements {
 		content = strings.ReplaceAll(content, placeholder, replacement)
 	}
+	for placeholder, replacement := range g.replacements {
+		content = strings.ReplaceAll(content, placeholder, replacement)
+	}
 
 	return content
 }
 
+// agentReference is the value of __AGENT__
+func (g *Generator) agentReference() string {
+	if len(g.agents) == 0 {
+		return g.assistant.Key
+	}
+	return strings.Join(g.agents, ",")
+}
+
 // getScriptReference returns the appropriate script reference for the assistant
 func (g *Generator) getScriptReference() string {
 	switch g.scriptType {
@@ -80,6 +118,10 @@ func (g *Generator) getScriptReference() string {
 		return "./setup.sh"
 	case config.ScriptTypePowerShell:
 		return ".\\setup.ps1"
+	case config.ScriptTypeFish:
+		return "./setup.fish"
+	case config.ScriptTypeNushell:
+		return "./setup.nu"
 	default:
 		return "./setup.sh"
 	}
@@ -89,7 +131,9 @@ func (g *Generator) getScriptReference() string {
 func (g *Generator) GenerateAllScripts() (map[string][]byte, error) {
 	scripts := make(map[string][]byte)
 
+	// common holds the helpers every other script sources
 	scriptNames := []string{
+		"common",
 		"check-prerequisites",
 		"create-new-feature",
 		"setup-plan",
@@ -108,6 +152,28 @@ func (g *Generator) GenerateAllScripts() (map[string][]byte, error) {
 	return scripts, nil
 }
 
+// GenerateScriptTree generates every embedded script for the current script type,
+// including helpers such as common.sh, keyed by its path relative to the
+// scripts asset directory (e.g. bash/setup-plan.sh)
+func (g *Generator) GenerateScriptTree() (map[string][]byte, error) {
+	scripts := make(map[string][]byte)
+	prefix := path.Dir(g.getScriptPath("")) + "/"
+
+	for _, name := range g.assets.ListScripts() {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		content, _ := g.assets.GetScript(name)
+		scripts[name] = []byte(g.applyReplacements(string(content)))
+	}
+
+	if len(scripts) == 0 {
+		return nil, errors.NewAssetNotFound(fmt.Sprintf("script templates in %s", prefix))
+	}
+
+	return scripts, nil
+}
+
 // ValidateScriptType validates the script type is supported
 func (g *Generator) ValidateScriptType() error {
 	return ValidateScriptType(g.scriptType)
@@ -2,11 +2,15 @@
 package templates
 
 import (
+	"fmt"
 	"io/fs"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	gospecify "github.com/jsburckhardt/spec-kit/gospecify"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
 )
 
 // EmbeddedAssets holds all embedded template and script assets
@@ -15,34 +19,60 @@ type EmbeddedAssets struct {
 	Scripts   map[string][]byte
 }
 
-// LoadEmbeddedAssets loads all embedded assets into memory
+// LoadEmbeddedAssets loads the default embedded template set into memory
 func LoadEmbeddedAssets() (*EmbeddedAssets, error) {
+	return LoadEmbeddedAssetSet(config.DefaultTemplateSet)
+}
+
+// LoadEmbeddedAssetSet loads the named embedded template set into memory after
+// verifying the embedded assets against the build-time checksum manifest.
+// The default set lives directly under assets/, other sets under assets/sets/<name>/.
+func LoadEmbeddedAssetSet(setName string) (*EmbeddedAssets, error) {
+	if err := VerifyEmbeddedAssets(); err != nil {
+		return nil, err
+	}
+
+	root := templateSetRoot(setName)
+	assetsFS := gospecify.GetAssetsFS()
+	if _, err := fs.Stat(assetsFS, root); err != nil {
+		return nil, errors.NewAssetNotFound(fmt.Sprintf("template set %s", setName))
+	}
+
+	return loadAssetsFS(assetsFS, root)
+}
+
+// loadAssetsFS reads the templates/ and scripts/ trees below root in fsys
+func loadAssetsFS(fsys fs.FS, root string) (*EmbeddedAssets, error) {
 	assets := &EmbeddedAssets{
 		Templates: make(map[string][]byte),
 		Scripts:   make(map[string][]byte),
 	}
 
-	assetsFS := gospecify.GetAssetsFS()
+	templatesPrefix := root + "/templates/"
+	scriptsPrefix := root + "/scripts/"
+	if root == "." {
+		templatesPrefix, scriptsPrefix = "templates/", "scripts/"
+	}
 
 	// Load all assets
-	err := fs.WalkDir(assetsFS, "assets", func(path string, d fs.DirEntry, err error) error {
+	err := fs.WalkDir(fsys, root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil || d.IsDir() {
 			return err
 		}
 
-		content, err := assetsFS.ReadFile(path)
+		content, err := fs.ReadFile(fsys, path)
 		if err != nil {
 			return err
 		}
 
 		// Determine if this is a template or script based on path
-		if strings.HasPrefix(path, "assets/templates/") {
-			relativePath := strings.TrimPrefix(path, "assets/templates/")
+		if strings.HasPrefix(path, templatesPrefix) {
+			relativePath := strings.TrimPrefix(path, templatesPrefix)
 			if relativePath != "" {
 				assets.Templates[filepath.ToSlash(relativePath)] = content
 			}
-		} else if strings.HasPrefix(path, "assets/scripts/") {
-			relativePath := strings.TrimPrefix(path, "assets/scripts/")
+		} else if strings.HasPrefix(path, scriptsPrefix) {
+			relativePath := strings.TrimPrefix(path, scriptsPrefix)
 			if relativePath != "" {
 				assets.Scripts[filepath.ToSlash(relativePath)] = content
 			}
@@ -58,6 +88,31 @@ func LoadEmbeddedAssets() (*EmbeddedAssets, error) {
 	return assets, nil
 }
 
+// ListTemplateSets returns the sorted names of all embedded template sets
+func ListTemplateSets() []string {
+	names := []string{config.DefaultTemplateSet}
+
+	entries, err := fs.ReadDir(gospecify.GetAssetsFS(), "assets/sets")
+	if err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() && entry.Name() != config.DefaultTemplateSet {
+				names = append(names, entry.Name())
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// templateSetRoot returns the embedded directory holding a template set
+func templateSetRoot(setName string) string {
+	if setName == "" || setName == config.DefaultTemplateSet {
+		return "assets"
+	}
+	return "assets/sets/" + setName
+}
+
 // GetTemplate retrieves a template by name
 func (ea *EmbeddedAssets) GetTemplate(name string) ([]byte, bool) {
 	content, exists := ea.Templates[name]
@@ -87,3 +142,31 @@ func (ea *EmbeddedAssets) ListScripts() []string {
 	}
 	return names
 }
+
+// editorRoot is the embedded directory holding per-assistant editor files
+const editorRoot = "assets/editor"
+
+// EditorFiles returns the embedded editor recommendation files for an assistant,
+// keyed by file name, or an empty map if there are none
+func EditorFiles(assistantKey string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	assetsFS := gospecify.GetAssetsFS()
+
+	entries, err := fs.ReadDir(assetsFS, editorRoot+"/"+assistantKey)
+	if err != nil {
+		return files, nil
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		content, err := assetsFS.ReadFile(editorRoot + "/" + assistantKey + "/" + entry.Name())
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrCodeAssetNotFound, "failed to read editor file "+entry.Name(), err)
+		}
+		files[entry.Name()] = content
+	}
+
+	return files, nil
+}
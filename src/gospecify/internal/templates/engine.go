@@ -0,0 +1,103 @@
+// Package templates provides template processing functionality
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// GoTemplateExtension marks a template to be rendered with text/template; it
+// is dropped from the output name, so plan.md.tmpl is written as plan.md
+const GoTemplateExtension = ".tmpl"
+
+// goTemplateMarker is the front matter line that selects text/template for a
+// template without the .tmpl extension
+const goTemplateMarker = "template: go"
+
+// templateData is what Go templates see: the assistant's fields (such as
+// .IsIDEBased and .Key) at the top level, plus the script type and values
+type templateData struct {
+	config.AIAssistant
+	// Script is the selected script type, e.g. .Script.Key or .Script.Extension
+	Script config.ScriptType
+	// Agents lists the keys of every assistant sharing the project
+	Agents []string
+	// Values holds --owner and --set values by name, e.g. .Values.owner
+	Values map[string]string
+}
+
+// OutputName returns the name a template is written under
+func OutputName(templateName string) string {
+	return strings.TrimSuffix(templateName, GoTemplateExtension)
+}
+
+// usesGoTemplate reports whether a template is rendered with text/template,
+// returning its content with the front matter marker removed
+func usesGoTemplate(templateName, content string) (bool, string) {
+	if strings.HasSuffix(templateName, GoTemplateExtension) {
+		return true, content
+	}
+
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return false, content
+	}
+	for i, line := range lines[1:] {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "---" {
+			break
+		}
+		if trimmed != goTemplateMarker {
+			continue
+		}
+		lines = append(lines[:i+1], lines[i+2:]...)
+		// Front matter that only held the marker is dropped entirely
+		if strings.TrimSpace(lines[1]) == "---" {
+			lines = lines[2:]
+		}
+		return true, strings.Join(lines, "\n")
+	}
+	return false, content
+}
+
+// executeGoTemplate renders content with text/template. A missing value is
+// an error, which makes {{.Values.name}} a required placeholder.
+func (p *Processor) executeGoTemplate(templateName, content string) (string, error) {
+	tmpl, err := template.New(templateName).Option("missingkey=error").Parse(content)
+	if err != nil {
+		return "", errors.NewTemplateError(fmt.Sprintf("template %s is not a valid Go template", templateName), err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, p.templateData()); err != nil {
+		return "", errors.NewTemplateError(fmt.Sprintf("failed to render template %s", templateName), err)
+	}
+	return rendered.String(), nil
+}
+
+// templateData builds the data Go templates are executed with
+func (p *Processor) templateData() templateData {
+	values := make(map[string]string)
+	for placeholder, value := range p.replacements {
+		if name, ok := strings.CutPrefix(placeholder, "{{"); ok {
+			values[strings.TrimSuffix(name, "}}")] = value
+		}
+	}
+
+	agents := p.agents
+	if len(agents) == 0 {
+		agents = []string{p.assistant.Key}
+	}
+
+	return templateData{
+		AIAssistant: *p.assistant,
+		Script:      config.ScriptTypes[p.scriptType],
+		Agents:      agents,
+		Values:      values,
+	}
+}
@@ -0,0 +1,91 @@
+// Package templates provides embedded template assets
+package templates
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	gospecify "github.com/jsburckhardt/spec-kit/gospecify"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// parseAssetManifest parses a sha256sum-style manifest into a path to hash map
+func parseAssetManifest(manifest []byte) (map[string]string, error) {
+	entries := make(map[string]string)
+
+	scanner := bufio.NewScanner(bytes.NewReader(manifest))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 || len(fields[0]) != sha256.Size*2 {
+			return nil, errors.NewAssetCorrupted("manifest", fmt.Sprintf("malformed entry %q", line))
+		}
+
+		// sha256sum marks binary-mode entries with a leading '*'
+		entries[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.NewAssetCorrupted("manifest", err.Error())
+	}
+
+	return entries, nil
+}
+
+// VerifyEmbeddedAssets checks every embedded asset against the build-time manifest
+func VerifyEmbeddedAssets() error {
+	expected, err := parseAssetManifest(gospecify.GetAssetsManifest())
+	if err != nil {
+		return err
+	}
+	if len(expected) == 0 {
+		return errors.NewAssetCorrupted("manifest", "manifest is empty")
+	}
+
+	assetsFS := gospecify.GetAssetsFS()
+	seen := make(map[string]bool, len(expected))
+
+	err = fs.WalkDir(assetsFS, "assets", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		want, listed := expected[path]
+		if !listed {
+			return errors.NewAssetCorrupted(path, "not listed in manifest")
+		}
+		seen[path] = true
+
+		content, err := assetsFS.ReadFile(path)
+		if err != nil {
+			return errors.NewAssetCorrupted(path, err.Error())
+		}
+
+		sum := sha256.Sum256(content)
+		if got := hex.EncodeToString(sum[:]); got != want {
+			return errors.NewAssetCorrupted(path, fmt.Sprintf("expected sha256 %s, got %s", want, got))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for path := range expected {
+		if !seen[path] {
+			return errors.NewAssetCorrupted(path, "missing from binary")
+		}
+	}
+
+	return nil
+}
@@ -3,17 +3,37 @@ package templates
 
 import (
 	"fmt"
+	"path"
+	"regexp"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 
+	"github.com/BurntSushi/toml"
 	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
 	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
 )
 
+// scriptPlaceholder matches {SCRIPT} and {SCRIPT:name}, which stand for generated scripts
+var scriptPlaceholder = regexp.MustCompile(`\{SCRIPT(?::([A-Za-z0-9_-]+))?\}`)
+
+// frontMatterScript matches a "sh: scripts/bash/setup-plan.sh --json" entry in a template's front matter
+var frontMatterScript = regexp.MustCompile(`^\s*(sh|ps|fish|nu):\s*scripts/(\S+)(.*)$`)
+
+// requiredMarker matches {{!required:name}}, a placeholder that must be given a value
+var requiredMarker = regexp.MustCompile(`\{\{!required:([A-Za-z0-9_.-]+)\}\}`)
+
 // Processor handles template processing for different AI assistants
 type Processor struct {
-	assets     *EmbeddedAssets
-	assistant  *config.AIAssistant
-	scriptType string
+	assets       *EmbeddedAssets
+	assistant    *config.AIAssistant
+	scriptType   string
+	replacements map[string]string
+	fixEndings   bool
+	agents       []string
+	concurrency  int
+	keepLayout   bool
 }
 
 // NewProcessor creates a new template processor
@@ -25,6 +45,65 @@ func NewProcessor(assets *EmbeddedAssets, assistant *config.AIAssistant, scriptT
 	}
 }
 
+// WithReplacements adds placeholder values applied after the built-in replacements
+func (p *Processor) WithReplacements(replacements map[string]string) *Processor {
+	if p.replacements == nil {
+		p.replacements = make(map[string]string, len(replacements))
+	}
+	for placeholder, value := range replacements {
+		p.replacements[placeholder] = value
+	}
+	return p
+}
+
+// WithTemplateStructure resolves script placeholders to the template archive
+// layout (.specify/scripts/bash/...) instead of the flattened .specify/scripts/
+func (p *Processor) WithTemplateStructure(keep bool) *Processor {
+	p.keepLayout = keep
+	return p
+}
+
+// WithAgents sets the assistants sharing the project. __AGENT__ then expands
+// to their comma-separated keys, which update-agent-context accepts, instead
+// of the processor's own assistant.
+func (p *Processor) WithAgents(keys []string) *Processor {
+	p.agents = keys
+	return p
+}
+
+// WithConcurrency sets how many templates ProcessAllTemplates processes in
+// parallel; zero or less means runtime.NumCPU()
+func (p *Processor) WithConcurrency(n int) *Processor {
+	p.concurrency = n
+	return p
+}
+
+// WithLineEndingFix normalizes templates with mixed line endings to LF before processing
+func (p *Processor) WithLineEndingFix(fix bool) *Processor {
+	p.fixEndings = fix
+	return p
+}
+
+// MixedLineEndings returns the sorted names of templates that mix CRLF and LF
+// line endings, which usually means a template was edited on another platform
+func MixedLineEndings(assets *EmbeddedAssets) []string {
+	var mixed []string
+	for _, templateName := range assets.ListTemplates() {
+		content, _ := assets.GetTemplate(templateName)
+		if hasMixedLineEndings(string(content)) {
+			mixed = append(mixed, templateName)
+		}
+	}
+	sort.Strings(mixed)
+	return mixed
+}
+
+// hasMixedLineEndings reports whether content has both CRLF and bare LF line endings
+func hasMixedLineEndings(content string) bool {
+	crlf := strings.Count(content, "\r\n")
+	return crlf > 0 && crlf < strings.Count(content, "\n")
+}
+
 // ProcessTemplate processes a template and returns the processed content
 func (p *Processor) ProcessTemplate(templateName string) ([]byte, error) {
 	template, exists := p.assets.GetTemplate(templateName)
@@ -33,6 +112,23 @@ func (p *Processor) ProcessTemplate(templateName string) ([]byte, error) {
 	}
 
 	content := string(template)
+	if p.fixEndings && hasMixedLineEndings(content) {
+		content = strings.ReplaceAll(content, "\r\n", "\n")
+	}
+
+	// Go templates run first; the legacy placeholders still apply to their output
+	if goTemplate, body := usesGoTemplate(templateName, content); goTemplate {
+		rendered, err := p.executeGoTemplate(templateName, body)
+		if err != nil {
+			return nil, err
+		}
+		content = rendered
+	}
+
+	if missing := p.missingRequired(content); len(missing) > 0 {
+		return nil, errors.NewTemplateError(fmt.Sprintf(
+			"template %s requires values for: %s", templateName, strings.Join(missing, ", ")), nil)
+	}
 
 	// Apply replacements
 	content = p.applyReplacements(content)
@@ -42,7 +138,7 @@ func (p *Processor) ProcessTemplate(templateName string) ([]byte, error) {
 	case config.FormatMarkdown:
 		return p.processMarkdownTemplate(content)
 	case config.FormatTOML:
-		return p.processTOMLTemplate(content)
+		return p.processTOMLTemplate(OutputName(templateName), content)
 	case config.FormatPrompt:
 		return p.processPromptTemplate(content)
 	default:
@@ -50,40 +146,129 @@ func (p *Processor) ProcessTemplate(templateName string) ([]byte, error) {
 	}
 }
 
+// ReservedPlaceholders are filled in by the processor itself and cannot be
+// given values through WithReplacements
+var ReservedPlaceholders = []string{"__AGENT__", "$ARGUMENTS", "{{args}}", "{SCRIPT}"}
+
 // applyReplacements applies common placeholder replacements
 func (p *Processor) applyReplacements(content string) string {
 	replacements := map[string]string{
-		"__AGENT__":  p.assistant.Key,
+		"__AGENT__":  agentReference(p.assistant, p.agents),
 		"$ARGUMENTS": p.assistant.ArgFormat,
 		"{{args}}":   p.assistant.ArgFormat,
-		"{SCRIPT}":   "", // Will be set per template
 	}
 
 	for placeholder, replacement := range replacements {
 		content = strings.ReplaceAll(content, placeholder, replacement)
 	}
+	content = p.resolveScripts(content)
+	content = requiredMarker.ReplaceAllStringFunc(content, func(marker string) string {
+		name := requiredMarker.FindStringSubmatch(marker)[1]
+		return "{{" + name + "}}"
+	})
+	for placeholder, replacement := range p.replacements {
+		content = strings.ReplaceAll(content, placeholder, replacement)
+	}
 
 	return content
 }
 
-// processMarkdownTemplate processes Markdown format templates
-func (p *Processor) processMarkdownTemplate(content string) ([]byte, error) {
-	lines := strings.Split(content, "\n")
-	var processed []string
+// agentReference is the value of __AGENT__: the assistant's key, or every
+// key of the assistants sharing the project
+func agentReference(assistant *config.AIAssistant, agents []string) string {
+	if len(agents) == 0 {
+		return assistant.Key
+	}
+	return strings.Join(agents, ",")
+}
 
-	for _, line := range lines {
-		// Handle script placeholders in Markdown
-		if strings.Contains(line, "{SCRIPT}") {
-			line = strings.ReplaceAll(line, "{SCRIPT}", p.getScriptPath())
+// missingRequired returns the sorted names of required markers in content
+// that have no replacement value
+func (p *Processor) missingRequired(content string) []string {
+	seen := make(map[string]bool)
+	var missing []string
+	for _, match := range requiredMarker.FindAllStringSubmatch(content, -1) {
+		name := match[1]
+		if _, supplied := p.replacements["{{"+name+"}}"]; supplied || seen[name] {
+			continue
 		}
-		processed = append(processed, line)
+		seen[name] = true
+		missing = append(missing, name)
 	}
+	sort.Strings(missing)
+	return missing
+}
 
-	return []byte(strings.Join(processed, "\n")), nil
+// resolveScripts replaces {SCRIPT:name} with the path of the generated script
+// name. Bare {SCRIPT} becomes the command the template's front matter gives
+// for the script type, or the setup script when there is none.
+func (p *Processor) resolveScripts(content string) string {
+	command := p.frontMatterCommand(content)
+	return scriptPlaceholder.ReplaceAllStringFunc(content, func(token string) string {
+		name := scriptPlaceholder.FindStringSubmatch(token)[1]
+		if name == "" {
+			if command != "" {
+				return command
+			}
+			name = "setup"
+		}
+		return p.getScriptPath(name + p.scriptExtension())
+	})
 }
 
-// processTOMLTemplate processes TOML format templates
-func (p *Processor) processTOMLTemplate(content string) ([]byte, error) {
+// frontMatterCommand returns the scripts entry for the processor's script
+// type in content's front matter, with the script path resolved, or ""
+func (p *Processor) frontMatterCommand(content string) string {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return ""
+	}
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == "---" {
+			break
+		}
+		match := frontMatterScript.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if match == nil || match[1] != p.scriptType {
+			continue
+		}
+		if p.keepLayout {
+			return ".specify/scripts/" + match[2] + match[3]
+		}
+		return p.getScriptPath(path.Base(match[2])) + match[3]
+	}
+	return ""
+}
+
+// processMarkdownTemplate processes Markdown format templates; script
+// placeholders are already resolved by applyReplacements
+func (p *Processor) processMarkdownTemplate(content string) ([]byte, error) {
+	return []byte(content), nil
+}
+
+// processTOMLTemplate processes TOML format templates. Markdown command
+// templates are converted to the TOML command format, and every command file
+// must parse afterwards, since a replaced value containing quotes can break
+// the document. Other templates stay as they are.
+func (p *Processor) processTOMLTemplate(templateName, content string) ([]byte, error) {
+	isCommand := strings.HasPrefix(templateName, "commands/")
+	switch {
+	case strings.HasSuffix(templateName, ".toml"):
+		content = p.expandTOMLPrompts(content)
+	case isCommand:
+		content = markdownToTOMLCommand(content)
+	default:
+		return []byte(content), nil
+	}
+
+	if err := validateTOML(templateName, content); err != nil {
+		return nil, err
+	}
+	return []byte(content), nil
+}
+
+// expandTOMLPrompts fills in the argument placeholders inside the prompt
+// sections of a template that is a TOML document itself
+func (p *Processor) expandTOMLPrompts(content string) string {
 	lines := strings.Split(content, "\n")
 	var processed []string
 
@@ -105,45 +290,225 @@ func (p *Processor) processTOMLTemplate(content string) ([]byte, error) {
 		processed = append(processed, line)
 	}
 
-	return []byte(strings.Join(processed, "\n")), nil
+	return strings.Join(processed, "\n")
+}
+
+// markdownToTOMLCommand converts a Markdown command template into a TOML
+// command: the front matter description becomes description, and the body
+// the prompt
+func markdownToTOMLCommand(content string) string {
+	description, body := splitFrontMatter(content)
+	body = strings.Trim(body, "\n")
+
+	// A multi-line basic string needs backslashes and runs of three quotes escaped
+	prompt := strings.ReplaceAll(body, `\`, `\\`)
+	prompt = strings.ReplaceAll(prompt, `"""`, `""\"`)
+
+	var doc strings.Builder
+	if description != "" {
+		doc.WriteString("description = " + tomlString(description) + "\n\n")
+	}
+	doc.WriteString("prompt = \"\"\"\n" + prompt + "\n\"\"\"\n")
+	return doc.String()
+}
+
+// splitFrontMatter returns the description from the front matter of a
+// Markdown command template, and the body that follows the front matter
+func splitFrontMatter(content string) (description, body string) {
+	body = content
+	lines := strings.Split(content, "\n")
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		for i, line := range lines[1:] {
+			if strings.TrimSpace(line) == "---" {
+				body = strings.Join(lines[i+2:], "\n")
+				break
+			}
+			if value, ok := strings.CutPrefix(strings.TrimSpace(line), "description:"); ok {
+				description = strings.Trim(strings.TrimSpace(value), `"'`)
+			}
+		}
+	}
+	return description, body
+}
+
+// CommandDescription returns the description of a command template, taken
+// from the front matter of a Markdown template or the description key of a
+// TOML one, or "" if it has none
+func CommandDescription(templateName string, content []byte) string {
+	if strings.HasSuffix(templateName, ".toml") {
+		var command struct {
+			Description string `toml:"description"`
+		}
+		if _, err := toml.Decode(string(content), &command); err != nil {
+			return ""
+		}
+		return command.Description
+	}
+	description, _ := splitFrontMatter(string(content))
+	return description
+}
+
+// tomlString quotes s as a TOML basic string
+func tomlString(s string) string {
+	var quoted strings.Builder
+	quoted.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			quoted.WriteString(`\` + string(r))
+		case r == '\n':
+			quoted.WriteString(`\n`)
+		case r == '\t':
+			quoted.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&quoted, `\u%04X`, r)
+		default:
+			quoted.WriteRune(r)
+		}
+	}
+	quoted.WriteByte('"')
+	return quoted.String()
+}
+
+// validateTOML reports where processed content of templateName fails to parse as TOML
+func validateTOML(templateName, content string) error {
+	var document map[string]any
+	_, err := toml.Decode(content, &document)
+	if err == nil {
+		return nil
+	}
+	if parseErr, ok := err.(toml.ParseError); ok {
+		return errors.NewTemplateError(fmt.Sprintf("template %s is not valid TOML after processing, at line %d: %s",
+			templateName, parseErr.Position.Line, parseErr.Message), nil)
+	}
+	return errors.NewTemplateError(fmt.Sprintf("template %s is not valid TOML after processing", templateName), err)
 }
 
 // processPromptTemplate processes prompt.md format templates
 func (p *Processor) processPromptTemplate(content string) ([]byte, error) {
-	// Prompt templates are simpler, just apply basic replacements
-	content = strings.ReplaceAll(content, "{SCRIPT}", p.getScriptPath())
+	// Prompt templates need nothing beyond the common replacements
 	return []byte(content), nil
 }
 
-// getScriptPath returns the appropriate script path for the current configuration
-func (p *Processor) getScriptPath() string {
-	return fmt.Sprintf(".specify/scripts/%s", p.getScriptName())
+// getScriptPath returns the project path of the generated script fileName
+func (p *Processor) getScriptPath(fileName string) string {
+	if p.keepLayout {
+		return fmt.Sprintf(".specify/scripts/%s/%s", p.scriptDirectory(), fileName)
+	}
+	return fmt.Sprintf(".specify/scripts/%s", fileName)
+}
+
+// scriptDirectory returns the template archive directory of the current script type
+func (p *Processor) scriptDirectory() string {
+	switch p.scriptType {
+	case config.ScriptTypePowerShell:
+		return "powershell"
+	case config.ScriptTypeFish:
+		return "fish"
+	case config.ScriptTypeNushell:
+		return "nu"
+	default:
+		return "bash"
+	}
 }
 
-// getScriptName returns the script name for the current configuration
-func (p *Processor) getScriptName() string {
+// scriptExtension returns the file extension of the current script type
+func (p *Processor) scriptExtension() string {
 	switch p.scriptType {
-	case config.ScriptTypeBash:
-		return "setup.sh"
 	case config.ScriptTypePowerShell:
-		return "setup.ps1"
+		return ".ps1"
+	case config.ScriptTypeFish:
+		return ".fish"
+	case config.ScriptTypeNushell:
+		return ".nu"
 	default:
-		return "setup.sh"
+		return ".sh"
 	}
 }
 
-// ProcessAllTemplates processes all templates for the current assistant
+// ProcessAllTemplates processes all templates for the current assistant,
+// keyed by their OutputName
 func (p *Processor) ProcessAllTemplates() (map[string][]byte, error) {
 	processed := make(map[string][]byte)
 
 	templates := p.assets.ListTemplates()
+	sort.Strings(templates)
+
+	// plan.md and plan.md.tmpl would both be written as plan.md
+	sources := make(map[string]string)
 	for _, templateName := range templates {
-		content, err := p.ProcessTemplate(templateName)
+		outputName := OutputName(templateName)
+		if other, exists := sources[outputName]; exists {
+			return nil, errors.NewTemplateError(fmt.Sprintf(
+				"templates %s and %s would both be written as %s", other, templateName, outputName), nil)
+		}
+		sources[outputName] = templateName
+	}
+
+	// Report every missing required value at once rather than one template at a time
+	usedBy := make(map[string][]string)
+	for _, templateName := range templates {
+		content, _ := p.assets.GetTemplate(templateName)
+		for _, name := range p.missingRequired(string(content)) {
+			usedBy[name] = append(usedBy[name], templateName)
+		}
+	}
+	if len(usedBy) > 0 {
+		names := make([]string, 0, len(usedBy))
+		for name := range usedBy {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		lines := make([]string, 0, len(names))
+		for _, name := range names {
+			lines = append(lines, fmt.Sprintf("%s (in %s)", name, strings.Join(usedBy[name], ", ")))
+		}
+		return nil, errors.NewTemplateError(
+			"required template variables were not supplied:\n  "+strings.Join(lines, "\n  "), nil)
+	}
+
+	concurrency := p.concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+	concurrency = max(1, min(concurrency, len(templates)))
+
+	// Errors are kept per template so the first one in name order is
+	// reported, whichever worker hit it first
+	errs := make([]error, len(templates))
+	jobs := make(chan int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				content, err := p.ProcessTemplate(templates[i])
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+				mu.Lock()
+				processed[OutputName(templates[i])] = content
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range templates {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i, err := range errs {
 		if err != nil {
 			return nil, errors.Wrap(errors.ErrCodeTemplateError,
-				fmt.Sprintf("failed to process template %s", templateName), err)
+				fmt.Sprintf("failed to process template %s", templates[i]), err)
 		}
-		processed[templateName] = content
 	}
 
 	return processed, nil
@@ -0,0 +1,73 @@
+package templates
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+)
+
+// syntheticAssets returns n command templates shaped like the shipped ones,
+// with front matter, script placeholders and a long Markdown body
+func syntheticAssets(n int) *EmbeddedAssets {
+	body := strings.Repeat("Run `{SCRIPT:check-prerequisites}` and consider $ARGUMENTS before editing [PROJECT_NAME].\n", 60)
+	assets := &EmbeddedAssets{Templates: make(map[string][]byte, n)}
+	for i := range n {
+		assets.Templates[fmt.Sprintf("commands/command-%03d.md", i)] = []byte(fmt.Sprintf(`---
+description: Synthetic command %d
+scripts:
+  sh: scripts/bash/setup-plan.sh --json
+  ps: scripts/powershell/setup-plan.ps1 -Json
+---
+
+1. Run `+"`{SCRIPT}`"+` from the repo root.
+%s`, i, body))
+	}
+	return assets
+}
+
+func TestProcessAllTemplatesConcurrency(t *testing.T) {
+	assets := syntheticAssets(50)
+	assistant := config.AIAssistants["claude"]
+
+	serial, err := NewProcessor(assets, &assistant, config.ScriptTypePowerShell).WithConcurrency(1).ProcessAllTemplates()
+	if err != nil {
+		t.Fatalf("ProcessAllTemplates() error = %v", err)
+	}
+	parallel, err := NewProcessor(assets, &assistant, config.ScriptTypePowerShell).WithConcurrency(8).ProcessAllTemplates()
+	if err != nil {
+		t.Fatalf("ProcessAllTemplates() error = %v", err)
+	}
+
+	if len(serial) != len(assets.Templates) || len(parallel) != len(serial) {
+		t.Fatalf("processed %d and %d templates, want %d", len(serial), len(parallel), len(assets.Templates))
+	}
+	for name, content := range serial {
+		if string(parallel[name]) != string(content) {
+			t.Errorf("%s differs between serial and parallel processing", name)
+		}
+		if strings.Contains(string(content), "{SCRIPT") {
+			t.Errorf("%s still contains a script placeholder", name)
+		}
+	}
+}
+
+func BenchmarkProcessAllTemplates(b *testing.B) {
+	assets := syntheticAssets(200)
+	assistant := config.AIAssistants["claude"]
+	for _, run := range []struct {
+		name        string
+		concurrency int
+	}{{"serial", 1}, {"numcpu", runtime.NumCPU()}} {
+		b.Run(run.name, func(b *testing.B) {
+			processor := NewProcessor(assets, &assistant, config.ScriptTypePowerShell).WithConcurrency(run.concurrency)
+			for b.Loop() {
+				if _, err := processor.ProcessAllTemplates(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Package templates provides template processing functionality
+package templates
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// AssetSource provides the templates and scripts a project is generated from
+type AssetSource interface {
+	// Load reads the source's templates and scripts into memory
+	Load() (*EmbeddedAssets, error)
+	// Describe returns a short description of the source for progress output
+	Describe() string
+}
+
+// EmbeddedSource loads a template set compiled into the binary
+type EmbeddedSource struct {
+	SetName string
+}
+
+// Load implements AssetSource
+func (s EmbeddedSource) Load() (*EmbeddedAssets, error) {
+	return LoadEmbeddedAssetSet(s.SetName)
+}
+
+// Describe implements AssetSource
+func (s EmbeddedSource) Describe() string {
+	return fmt.Sprintf("embedded template set %s", s.SetName)
+}
+
+// DirSource loads templates/ and scripts/ from a directory on disk
+type DirSource struct {
+	Root string
+}
+
+// Load implements AssetSource
+func (s DirSource) Load() (*EmbeddedAssets, error) {
+	dirFS := os.DirFS(s.Root)
+	if _, err := fs.Stat(dirFS, "templates"); err != nil {
+		return nil, errors.NewAssetNotFound(fmt.Sprintf("templates directory in %s", s.Root))
+	}
+
+	assets, err := loadAssetsFS(dirFS, ".")
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeFileSystemError, fmt.Sprintf("failed to read assets from %s", s.Root), err)
+	}
+	return assets, nil
+}
+
+// Describe implements AssetSource
+func (s DirSource) Describe() string {
+	return s.Root
+}
+
+// GitSource shallow-clones a git repository and loads its templates/ and scripts/
+type GitSource struct {
+	URL string
+	// Ref is an optional branch or tag to check out
+	Ref string
+	// TempDir holds the clone; empty means the system temp directory
+	TempDir string
+	// Proxy overrides git's proxy settings when set
+	Proxy string
+	// Logger, when set, receives the git command line at debug level
+	Logger *slog.Logger
+	// Context, when set, kills the clone once it is done
+	Context context.Context
+}
+
+// Load implements AssetSource. The clone is removed once the assets are in memory.
+func (s GitSource) Load() (*EmbeddedAssets, error) {
+	tempDir, err := os.MkdirTemp(s.TempDir, "gospecify-template-*")
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrCodeFileSystemError, "failed to create temporary directory", err)
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	var args []string
+	if s.Proxy != "" {
+		args = append(args, "-c", "http.proxy="+s.Proxy)
+	}
+	args = append(args, "clone", "--depth", "1", "--quiet")
+	if s.Ref != "" {
+		args = append(args, "--branch", s.Ref)
+	}
+	args = append(args, "--", s.URL, tempDir)
+
+	if s.Logger != nil {
+		s.Logger.Debug("running git", "args", redactedGitArgs(args))
+	}
+	ctx := s.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	// Never stop to ask for credentials; fail instead
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, errors.NewGitError(
+			fmt.Sprintf("failed to clone %s: %s", s.Describe(), strings.TrimSpace(string(output))), err)
+	}
+
+	return DirSource{Root: tempDir}.Load()
+}
+
+// redactedGitArgs returns args with any password in a URL hidden, for logging
+func redactedGitArgs(args []string) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = arg
+		if _, value, ok := strings.Cut(arg, "http.proxy="); ok {
+			arg = value
+		}
+		if u, err := url.Parse(arg); err == nil && u.User != nil {
+			redacted[i] = strings.Replace(redacted[i], arg, u.Redacted(), 1)
+		}
+	}
+	return redacted
+}
+
+// Describe implements AssetSource
+func (s GitSource) Describe() string {
+	if s.Ref != "" {
+		return s.URL + "@" + s.Ref
+	}
+	return s.URL
+}
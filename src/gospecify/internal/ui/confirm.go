@@ -0,0 +1,29 @@
+// Package ui provides terminal user interface components
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// stdin is shared by every text prompt so buffered answers are not lost between them
+var stdin = bufio.NewReader(os.Stdin)
+
+// Confirm asks a yes/no question on stdin, defaulting to no
+func Confirm(prompt string) (bool, error) {
+	fmt.Printf("%s %s ", prompt, GrayStyle.Render("[y/N]"))
+
+	answer, err := stdin.ReadString('\n')
+	if err != nil && answer == "" {
+		return false, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,105 @@
+// Package ui provides terminal user interface components
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// downloadBarWidth is the number of cells in the download progress bar
+const downloadBarWidth = 30
+
+// downloadRedrawInterval limits how often the progress line is redrawn
+const downloadRedrawInterval = 100 * time.Millisecond
+
+// spinnerFrames animate downloads whose total size is unknown
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// DownloadProgress redraws a single line showing how far a download has got:
+// a percentage bar when the total size is known, otherwise a spinner, and in
+// both cases the bytes transferred and the throughput
+type DownloadProgress struct {
+	out      io.Writer
+	label    string
+	started  time.Time
+	lastDraw time.Time
+	frame    int
+	drawn    bool
+	mu       sync.Mutex
+}
+
+// NewDownloadProgress creates a download progress line written to out
+func NewDownloadProgress(out io.Writer, label string) *DownloadProgress {
+	return &DownloadProgress{
+		out:   out,
+		label: label,
+	}
+}
+
+// Update records that written of total bytes have arrived; total is -1 when
+// the server did not send a length. Its signature matches the progress
+// callback of github.Client.DownloadAsset.
+func (d *DownloadProgress) Update(written, total int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	if d.started.IsZero() {
+		d.started = now
+	}
+	if d.drawn && now.Sub(d.lastDraw) < downloadRedrawInterval && (total <= 0 || written < total) {
+		return
+	}
+	d.lastDraw = now
+	d.drawn = true
+	_, _ = fmt.Fprintf(d.out, "\r%s\x1b[K", d.line(written, total, now))
+}
+
+// Finish ends the progress line so later output starts on a fresh line
+func (d *DownloadProgress) Finish() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.drawn {
+		_, _ = fmt.Fprintln(d.out)
+		d.drawn = false
+	}
+}
+
+// line renders the progress line for written of total bytes at now
+func (d *DownloadProgress) line(written, total int64, now time.Time) string {
+	var rate string
+	if elapsed := now.Sub(d.started).Seconds(); elapsed > 0 {
+		rate = formatBytes(int64(float64(written)/elapsed)) + "/s"
+	}
+
+	if total <= 0 {
+		spinner := spinnerFrames[d.frame%len(spinnerFrames)]
+		d.frame++
+		return strings.TrimSpace(fmt.Sprintf("%s %s %s  %s",
+			d.label, CyanStyle.Render(spinner), formatBytes(written), GrayStyle.Render(rate)))
+	}
+
+	fraction := min(float64(written)/float64(total), 1)
+	filled := int(fraction * downloadBarWidth)
+	bar := CyanStyle.Render(strings.Repeat("█", filled)) +
+		GrayStyle.Render(strings.Repeat("░", downloadBarWidth-filled))
+	return strings.TrimSpace(fmt.Sprintf("%s %s %3.0f%%  %s/%s  %s",
+		d.label, bar, fraction*100, formatBytes(written), formatBytes(total), GrayStyle.Render(rate)))
+}
+
+// formatBytes renders a byte count with a binary unit, e.g. 1.5 MiB
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
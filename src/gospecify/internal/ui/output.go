@@ -0,0 +1,446 @@
+// Package ui provides terminal user interface components
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// OutputRenderer presents the events of an init run to the user
+type OutputRenderer interface {
+	// Begin is called once all steps have been registered on the tracker
+	Begin(tracker *config.StepTracker)
+	// StepUpdate is called whenever a step changes status or detail
+	StepUpdate(step config.Step)
+	// Info reports a notable action, such as a file being removed
+	Info(message string)
+	// Warn reports a non-fatal problem
+	Warn(message string)
+	// Success reports a completed initialization
+	Success(result *config.InitResult)
+	// Error reports a failed initialization
+	Error(err error)
+}
+
+// HumanRenderer renders styled progress and panels for interactive use
+type HumanRenderer struct {
+	out      io.Writer
+	compact  bool
+	theme    *Theme
+	progress *LiveProgress
+}
+
+// NewHumanRenderer creates a renderer for decorated terminal output
+func NewHumanRenderer(out io.Writer, compact bool, theme *Theme) *HumanRenderer {
+	return &HumanRenderer{
+		out:     out,
+		compact: compact,
+		theme:   theme,
+	}
+}
+
+// Begin prints the progress tree, which then follows the tracker live
+func (r *HumanRenderer) Begin(tracker *config.StepTracker) {
+	r.progress = NewLiveProgress(tracker)
+	r.progress.SetCompact(r.compact)
+	r.progress.Start(r.out)
+}
+
+// Pause stops the progress tree redrawing the elapsed time of the running
+// step until the step changes, so an interactive selector can draw below it
+func (r *HumanRenderer) Pause() {
+	if r.progress != nil {
+		r.progress.Pause()
+	}
+}
+
+// Interrupt keeps the progress tree from being redrawn over output that
+// follows it, such as a confirmation prompt
+func (r *HumanRenderer) Interrupt() {
+	if r.progress != nil {
+		r.progress.Interrupt()
+	}
+}
+
+// DownloadProgress returns a progress line for a download, drawn on the renderer's output
+func (r *HumanRenderer) DownloadProgress(label string) *DownloadProgress {
+	r.Interrupt()
+	return NewDownloadProgress(r.out, label)
+}
+
+// StepUpdate is a no-op; the live progress tree redraws itself
+func (r *HumanRenderer) StepUpdate(step config.Step) {}
+
+// Info prints an informational line
+func (r *HumanRenderer) Info(message string) {
+	r.Interrupt()
+	_, _ = fmt.Fprintln(r.out, message)
+}
+
+// Warn prints a warning line
+func (r *HumanRenderer) Warn(message string) {
+	r.Interrupt()
+	_, _ = fmt.Fprintln(r.out, YellowStyle.Render("Warning: "+message))
+}
+
+// Success prints the success panel, security notice, and next steps
+func (r *HumanRenderer) Success(result *config.InitResult) {
+	r.stopProgress()
+	_, _ = fmt.Fprintln(r.out)
+	if result.DryRun {
+		_, _ = fmt.Fprintln(r.out, r.theme.InfoPanel.Render("Dry run complete: nothing was written to "+result.Path))
+		if len(result.Files) > 0 {
+			_, _ = fmt.Fprintln(r.out)
+			_, _ = fmt.Fprintln(r.out, RenderTree(result.Path, result.Files))
+		}
+		return
+	}
+	message := fmt.Sprintf("✅ Successfully initialized Specify project in %s", result.Path)
+	if len(result.AIAssistants) > 1 {
+		message += "\n\nAssistant directories created:"
+		for _, key := range result.AIAssistants {
+			message += "\n  " + config.AIAssistants[key].Directory
+		}
+	}
+	if result.Remote != "" {
+		message += "\n\nGit remote origin: " + result.Remote
+	}
+	_, _ = fmt.Fprintln(r.out, r.theme.InfoPanel.Render(message))
+	_, _ = fmt.Fprintln(r.out)
+
+	if len(result.Files) > 0 {
+		_, _ = fmt.Fprintln(r.out, RenderTree(result.Path, result.Files))
+		_, _ = fmt.Fprintln(r.out)
+	}
+
+	// Show security notice
+	if folders := agentFolders(result); len(folders) > 0 {
+		styled := make([]string, 0, len(folders))
+		for _, folder := range folders {
+			styled = append(styled, CyanStyle.Render(folder))
+		}
+		securityMessage := fmt.Sprintf(
+			"Some agents may store credentials, auth tokens, or other identifying and private artifacts in the agent folder within your project.\nConsider adding %s (or parts of it) to %s to prevent accidental credential leakage.",
+			strings.Join(styled, ", "),
+			CyanStyle.Render(".gitignore"))
+		if len(styled) > 1 {
+			securityMessage = fmt.Sprintf(
+				"Some agents may store credentials, auth tokens, or other identifying and private artifacts in their agent folders within your project.\nConsider adding these folders (or parts of them) to %s to prevent accidental credential leakage:\n  %s",
+				CyanStyle.Render(".gitignore"),
+				strings.Join(styled, "\n  "))
+		}
+		_, _ = fmt.Fprintln(r.out, r.theme.WarningPanel.Render(securityMessage))
+		_, _ = fmt.Fprintln(r.out)
+	}
+
+	// Show next steps; with --here the user is already in the project folder
+	var steps []string
+	if !result.Here {
+		steps = append(steps, fmt.Sprintf("%d. Go to the project folder: %s", len(steps)+1, CyanStyle.Render(fmt.Sprintf("cd %s", result.Name))))
+	}
+	if len(result.SlashCommands) > 0 {
+		steps = append(steps, fmt.Sprintf("%d. Start using slash commands with your AI agent:", len(steps)+1))
+	}
+	if len(steps) == 0 {
+		return
+	}
+	for _, command := range result.SlashCommands {
+		step := "   - " + CyanStyle.Render("/"+command.Name)
+		if command.Description != "" {
+			step += " - " + command.Description
+		}
+		steps = append(steps, step)
+	}
+
+	_, _ = fmt.Fprintln(r.out, r.theme.SuccessPanel.Render(strings.Join(steps, "\n")))
+}
+
+// Error stops the progress tree; the command reports the error itself
+func (r *HumanRenderer) Error(err error) {
+	r.stopProgress()
+}
+
+// stopProgress ends the live updates of the progress tree
+func (r *HumanRenderer) stopProgress() {
+	if r.progress != nil {
+		r.progress.Stop()
+	}
+}
+
+// joinWithAnd joins items as "a, b and c"
+func joinWithAnd(items []string) string {
+	if len(items) < 2 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+// agentFolders returns the agent folders that may hold credentials for the
+// assistants in result and are not yet covered by .gitignore
+func agentFolders(result *config.InitResult) []string {
+	keys := result.AIAssistants
+	if len(keys) == 0 {
+		keys = []string{result.AIAssistant}
+	}
+
+	var folders []string
+	for _, key := range keys {
+		if folder, exists := config.AgentFolderMap[key]; exists && !slices.Contains(result.Gitignored, folder) {
+			folders = append(folders, folder)
+		}
+	}
+	return folders
+}
+
+// AccessibleRenderer announces each step transition as a plain appended
+// line, without styling, cursor movement or panels, for screen readers
+type AccessibleRenderer struct {
+	out      io.Writer
+	statuses map[string]config.Status
+}
+
+// NewAccessibleRenderer creates a renderer for screen-reader friendly output
+func NewAccessibleRenderer(out io.Writer) *AccessibleRenderer {
+	return &AccessibleRenderer{
+		out:      out,
+		statuses: make(map[string]config.Status),
+	}
+}
+
+// accessibleStatus names each step status in plain words
+var accessibleStatus = map[config.Status]string{
+	config.StatusRunning: "started",
+	config.StatusDone:    "done",
+	config.StatusError:   "failed",
+	config.StatusSkipped: "skipped",
+}
+
+// Begin announces the run and how many steps it has
+func (r *AccessibleRenderer) Begin(tracker *config.StepTracker) {
+	_, _ = fmt.Fprintf(r.out, "%s: %d steps\n", tracker.GetTitle(), len(tracker.GetSteps()))
+}
+
+// StepUpdate prints one line when a step changes status; sub-progress is not announced
+func (r *AccessibleRenderer) StepUpdate(step config.Step) {
+	if r.statuses[step.Key] == step.Status {
+		return
+	}
+	r.statuses[step.Key] = step.Status
+
+	word, exists := accessibleStatus[step.Status]
+	if !exists {
+		return
+	}
+	line := fmt.Sprintf("%s: %s", step.Label, word)
+	if step.Detail != "" && step.Status != config.StatusRunning {
+		line += " (" + step.Detail + ")"
+	}
+	_, _ = fmt.Fprintln(r.out, line)
+}
+
+// Info prints an informational line
+func (r *AccessibleRenderer) Info(message string) {
+	_, _ = fmt.Fprintln(r.out, message)
+}
+
+// Warn prints a warning line
+func (r *AccessibleRenderer) Warn(message string) {
+	_, _ = fmt.Fprintln(r.out, "Warning: "+message)
+}
+
+// Success prints the result and next steps as plain lines
+func (r *AccessibleRenderer) Success(result *config.InitResult) {
+	if result.DryRun {
+		_, _ = fmt.Fprintf(r.out, "Dry run complete: nothing was written to %s\n", result.Path)
+		r.listFiles(result.Files)
+		return
+	}
+	_, _ = fmt.Fprintf(r.out, "Successfully initialized Specify project in %s\n", result.Path)
+	if result.Remote != "" {
+		_, _ = fmt.Fprintf(r.out, "Git remote origin: %s\n", result.Remote)
+	}
+	r.listFiles(result.Files)
+	if len(result.AIAssistants) > 1 {
+		for _, key := range result.AIAssistants {
+			_, _ = fmt.Fprintf(r.out, "Created assistant directory %s\n", config.AIAssistants[key].Directory)
+		}
+	}
+	if folders := agentFolders(result); len(folders) > 0 {
+		_, _ = fmt.Fprintf(r.out, "Security note: consider adding %s to .gitignore, as agents may store credentials there.\n", strings.Join(folders, ", "))
+	}
+	next := "Next, use"
+	if !result.Here {
+		_, _ = fmt.Fprintf(r.out, "Next, go to the project folder with: cd %s\n", result.Name)
+		next = "Then use"
+	}
+	if commands := result.SlashCommands; len(commands) > 0 {
+		names := make([]string, len(commands))
+		for i, command := range commands {
+			names[i] = "/" + command.Name
+		}
+		_, _ = fmt.Fprintf(r.out, "%s the slash commands %s with your AI agent.\n", next, joinWithAnd(names))
+	}
+}
+
+// Error is a no-op; the command reports the error itself
+func (r *AccessibleRenderer) Error(err error) {}
+
+// listFiles prints generated files one per line; box-drawing trees read poorly
+// on screen readers
+func (r *AccessibleRenderer) listFiles(files []string) {
+	if len(files) == 0 {
+		return
+	}
+	sorted := append([]string(nil), files...)
+	sort.Strings(sorted)
+	_, _ = fmt.Fprintf(r.out, "%d generated files:\n", len(sorted))
+	for _, file := range sorted {
+		_, _ = fmt.Fprintln(r.out, file)
+	}
+}
+
+// QuietRenderer prints nothing; the steps are still tracked and the
+// command reports errors itself
+type QuietRenderer struct {
+	tracker *config.StepTracker
+}
+
+// NewQuietRenderer creates a renderer for --quiet
+func NewQuietRenderer() *QuietRenderer {
+	return &QuietRenderer{}
+}
+
+// Begin keeps the tracker so its timings remain available
+func (r *QuietRenderer) Begin(tracker *config.StepTracker) {
+	r.tracker = tracker
+}
+
+// StepUpdate is a no-op
+func (r *QuietRenderer) StepUpdate(step config.Step) {}
+
+// Info is a no-op
+func (r *QuietRenderer) Info(message string) {}
+
+// Warn is a no-op
+func (r *QuietRenderer) Warn(message string) {}
+
+// Success is a no-op
+func (r *QuietRenderer) Success(result *config.InitResult) {}
+
+// Error is a no-op
+func (r *QuietRenderer) Error(err error) {}
+
+// JSONRenderer collects the run and emits a single JSON document at the end
+type JSONRenderer struct {
+	out      io.Writer
+	tracker  *config.StepTracker
+	messages []string
+	warnings []string
+}
+
+// NewJSONRenderer creates a renderer for machine-readable output
+func NewJSONRenderer(out io.Writer) *JSONRenderer {
+	return &JSONRenderer{
+		out: out,
+	}
+}
+
+// jsonStep is the JSON representation of a tracker step
+type jsonStep struct {
+	Key        string        `json:"key"`
+	Label      string        `json:"label"`
+	Status     config.Status `json:"status"`
+	Detail     string        `json:"detail,omitempty"`
+	DurationMS int64         `json:"duration_ms"`
+}
+
+// jsonError is the JSON representation of a failure
+type jsonError struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// jsonOutput is the document emitted by JSONRenderer
+type jsonOutput struct {
+	Success  bool               `json:"success"`
+	Project  *config.InitResult `json:"project,omitempty"`
+	Error    *jsonError         `json:"error,omitempty"`
+	Steps    []jsonStep         `json:"steps"`
+	Messages []string           `json:"messages"`
+	Warnings []string           `json:"warnings"`
+}
+
+// Begin records the tracker so step timings can be reported
+func (r *JSONRenderer) Begin(tracker *config.StepTracker) {
+	r.tracker = tracker
+}
+
+// StepUpdate is a no-op; steps are reported once at the end
+func (r *JSONRenderer) StepUpdate(step config.Step) {}
+
+// Info records a message for the final document
+func (r *JSONRenderer) Info(message string) {
+	r.messages = append(r.messages, message)
+}
+
+// Warn records a warning for the final document
+func (r *JSONRenderer) Warn(message string) {
+	r.warnings = append(r.warnings, message)
+}
+
+// Success emits the final document for a completed run
+func (r *JSONRenderer) Success(result *config.InitResult) {
+	r.emit(jsonOutput{
+		Success: true,
+		Project: result,
+	})
+}
+
+// Error emits the final document for a failed run
+func (r *JSONRenderer) Error(err error) {
+	r.emit(jsonOutput{
+		Error: &jsonError{
+			Code:    errors.CodeOf(err),
+			Message: err.Error(),
+		},
+	})
+}
+
+// emit fills in steps and warnings and writes the document
+func (r *JSONRenderer) emit(doc jsonOutput) {
+	doc.Steps = []jsonStep{}
+	if r.tracker != nil {
+		for _, step := range r.tracker.GetSteps() {
+			entry := jsonStep{
+				Key:    step.Key,
+				Label:  step.Label,
+				Status: step.Status,
+				Detail: step.Detail,
+			}
+			if !step.Started.IsZero() && !step.Ended.IsZero() {
+				entry.DurationMS = step.Ended.Sub(step.Started).Milliseconds()
+			}
+			doc.Steps = append(doc.Steps, entry)
+		}
+	}
+
+	doc.Messages = r.messages
+	if doc.Messages == nil {
+		doc.Messages = []string{}
+	}
+	doc.Warnings = r.warnings
+	if doc.Warnings == nil {
+		doc.Warnings = []string{}
+	}
+
+	encoder := json.NewEncoder(r.out)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(doc)
+}
@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// newTestTracker returns a tracker with a finished and a pending step
+func newTestTracker() *config.StepTracker {
+	tracker := &config.StepTracker{Title: "Initialize Specify Project"}
+	tracker.Add("validate", "Validate configuration")
+	tracker.Add("git", "Initialize git repository")
+	return tracker
+}
+
+// runRenderer drives renderer through a run that succeeds with result, or
+// fails with runErr when it is non-nil
+func runRenderer(renderer OutputRenderer, result *config.InitResult, runErr error) {
+	tracker := newTestTracker()
+	tracker.AttachListener(renderer.StepUpdate)
+	renderer.Begin(tracker)
+	tracker.Start("validate", "")
+	tracker.Complete("validate", "Configuration valid")
+	renderer.Info("Removed 2 files")
+	renderer.Warn("git not found")
+	if runErr != nil {
+		tracker.Error("git", runErr.Error())
+		renderer.Error(runErr)
+		return
+	}
+	tracker.Skip("git", "--no-git flag")
+	renderer.Success(result)
+}
+
+func testResult() *config.InitResult {
+	return &config.InitResult{
+		Name:        "demo",
+		Path:        "/work/demo",
+		AIAssistant: "claude",
+		ScriptType:  config.ScriptTypeBash,
+		Files:       []string{".specify/scripts/setup-plan.sh", ".claude/commands/plan.md"},
+		Commands:    []string{"plan", "tasks"},
+		SlashCommands: []config.SlashCommand{
+			{Name: "plan", Description: "Plan the work."},
+			{Name: "tasks"},
+		},
+	}
+}
+
+func TestHumanRenderer(t *testing.T) {
+	var out bytes.Buffer
+	runRenderer(NewHumanRenderer(&out, false, NewTheme(config.UIConfig{})), testResult(), nil)
+
+	for _, want := range []string{
+		"Validate configuration",
+		"Removed 2 files",
+		"Warning: git not found",
+		"Successfully initialized Specify project in /work/demo",
+		".claude/",
+		"cd demo",
+		"/plan - Plan the work.",
+		"/tasks",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "/tasks -") {
+		t.Errorf("output describes /tasks, whose template has no description:\n%s", out.String())
+	}
+}
+
+func TestRenderersHereSkipCd(t *testing.T) {
+	result := testResult()
+	result.Here = true
+	for name, newRenderer := range map[string]func(*bytes.Buffer) OutputRenderer{
+		"human": func(out *bytes.Buffer) OutputRenderer {
+			return NewHumanRenderer(out, false, NewTheme(config.UIConfig{}))
+		},
+		"accessible": func(out *bytes.Buffer) OutputRenderer { return NewAccessibleRenderer(out) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			runRenderer(newRenderer(&out), result, nil)
+			if strings.Contains(out.String(), "cd demo") {
+				t.Errorf("output suggests cd with --here:\n%s", out.String())
+			}
+			if !strings.Contains(out.String(), "/plan") {
+				t.Errorf("output lacks the slash commands:\n%s", out.String())
+			}
+		})
+	}
+}
+
+func TestAccessibleRenderer(t *testing.T) {
+	var out bytes.Buffer
+	runRenderer(NewAccessibleRenderer(&out), testResult(), nil)
+
+	for _, want := range []string{
+		"Initialize Specify Project: 2 steps\n",
+		"Validate configuration: started\n",
+		"Validate configuration: done (Configuration valid)\n",
+		"Warning: git not found\n",
+		"Initialize git repository: skipped (--no-git flag)\n",
+		"Successfully initialized Specify project in /work/demo\n",
+		"2 generated files:\n.claude/commands/plan.md\n.specify/scripts/setup-plan.sh\n",
+		"Then use the slash commands /plan and /tasks with your AI agent.\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, out.String())
+		}
+	}
+	if strings.ContainsRune(out.String(), '\x1b') {
+		t.Errorf("output contains escape sequences:\n%q", out.String())
+	}
+}
+
+func TestJSONRenderer(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var out bytes.Buffer
+		runRenderer(NewJSONRenderer(&out), testResult(), nil)
+
+		var doc jsonOutput
+		if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+			t.Fatalf("output is not one JSON document: %v\n%s", err, out.String())
+		}
+		if !doc.Success || doc.Error != nil {
+			t.Errorf("success = %v, error = %v, want a successful document", doc.Success, doc.Error)
+		}
+		if doc.Project == nil || doc.Project.Path != "/work/demo" {
+			t.Errorf("project = %+v, want path /work/demo", doc.Project)
+		}
+		if len(doc.Steps) != 2 || doc.Steps[0].Status != config.StatusDone || doc.Steps[1].Status != config.StatusSkipped {
+			t.Errorf("steps = %+v, want validate done and git skipped", doc.Steps)
+		}
+		if len(doc.Messages) != 1 || len(doc.Warnings) != 1 {
+			t.Errorf("messages = %v, warnings = %v, want one of each", doc.Messages, doc.Warnings)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var out bytes.Buffer
+		runRenderer(NewJSONRenderer(&out), nil, errors.NewValidationError("bad name"))
+
+		var doc jsonOutput
+		if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+			t.Fatalf("output is not one JSON document: %v\n%s", err, out.String())
+		}
+		if doc.Success || doc.Project != nil {
+			t.Errorf("success = %v, project = %v, want a failed document", doc.Success, doc.Project)
+		}
+		if doc.Error == nil || doc.Error.Code != errors.ErrCodeValidationError || !strings.Contains(doc.Error.Message, "bad name") {
+			t.Errorf("error = %+v, want the validation error", doc.Error)
+		}
+	})
+}
+
+func TestQuietRenderer(t *testing.T) {
+	renderer := NewQuietRenderer()
+	runRenderer(renderer, testResult(), nil)
+
+	if renderer.tracker == nil || len(renderer.tracker.GetSteps()) != 2 {
+		t.Error("quiet renderer did not keep the tracker")
+	}
+}
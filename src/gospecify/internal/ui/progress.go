@@ -3,15 +3,21 @@ package ui
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"golang.org/x/term"
 )
 
 // ProgressRenderer handles rendering of progress tracking
 type ProgressRenderer struct {
 	tracker *config.StepTracker
+	compact bool
 }
 
 // NewProgressRenderer creates a new progress renderer
@@ -34,6 +40,11 @@ func (pr *ProgressRenderer) Render() string {
 
 	// Steps
 	steps := pr.tracker.GetSteps()
+	if pr.compact {
+		pr.renderCompact(&output, steps)
+		return output.String()
+	}
+
 	for _, step := range steps {
 		line := pr.renderStep(step)
 		output.WriteString(line + "\n")
@@ -42,6 +53,43 @@ func (pr *ProgressRenderer) Render() string {
 	return output.String()
 }
 
+// SetCompact toggles compact rendering, where finished steps collapse into a
+// single summary line and only running or failed steps are expanded
+func (pr *ProgressRenderer) SetCompact(compact bool) {
+	pr.compact = compact
+}
+
+// renderCompact renders the collapsed view of the steps
+func (pr *ProgressRenderer) renderCompact(output *strings.Builder, steps []config.Step) {
+	var finished, pending int
+	var expanded []config.Step
+
+	for _, step := range steps {
+		switch step.Status {
+		case config.StatusDone, config.StatusSkipped:
+			finished++
+		case config.StatusRunning, config.StatusError:
+			expanded = append(expanded, step)
+		default: // StatusPending
+			pending++
+		}
+	}
+
+	if finished > 0 {
+		summary := fmt.Sprintf("%s %d/%d steps completed", ProgressDone, finished, len(steps))
+		output.WriteString(summary + "\n")
+	}
+
+	for _, step := range expanded {
+		output.WriteString(pr.renderStep(step) + "\n")
+	}
+
+	if pending > 0 {
+		remaining := GrayStyle.Render(fmt.Sprintf("○ %d steps remaining", pending))
+		output.WriteString(remaining + "\n")
+	}
+}
+
 // renderStep renders a single step
 func (pr *ProgressRenderer) renderStep(step config.Step) string {
 	var symbol, style string
@@ -71,6 +119,9 @@ func (pr *ProgressRenderer) renderStep(step config.Step) string {
 
 	// Build the label
 	label := step.Label
+	if step.Total > 0 {
+		label = fmt.Sprintf("%s %d/%d", label, step.Current, step.Total)
+	}
 	if step.Detail != "" {
 		if step.Status == config.StatusPending {
 			label = fmt.Sprintf("%s (%s)", label, step.Detail)
@@ -83,6 +134,10 @@ func (pr *ProgressRenderer) renderStep(step config.Step) string {
 		}
 	}
 
+	if elapsed := stepElapsed(step, time.Now()); elapsed != "" {
+		label += " " + GrayStyle.Render("("+elapsed+")")
+	}
+
 	// Apply pending style to entire line if needed
 	if step.Status == config.StatusPending {
 		return lipgloss.NewStyle().
@@ -93,19 +148,201 @@ func (pr *ProgressRenderer) renderStep(step config.Step) string {
 	return fmt.Sprintf("%s %s", styledSymbol, label)
 }
 
-// LiveProgress provides live progress updates
+// stepElapsed formats how long step took, or has been running at now; it is
+// empty for steps that were skipped or never started
+func stepElapsed(step config.Step, now time.Time) string {
+	if step.Started.IsZero() {
+		return ""
+	}
+	var elapsed time.Duration
+	switch step.Status {
+	case config.StatusDone, config.StatusError:
+		if step.Ended.IsZero() {
+			return ""
+		}
+		elapsed = step.Ended.Sub(step.Started)
+	case config.StatusRunning:
+		elapsed = now.Sub(step.Started)
+	default:
+		return ""
+	}
+	return formatElapsed(elapsed)
+}
+
+// formatElapsed renders d as tenths of a second below a minute, e.g. 1.2s,
+// and to the second above it, e.g. 2m5s
+func formatElapsed(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return d.Round(time.Second).String()
+}
+
+// elapsedRedrawInterval is how often the elapsed time of a running step is redrawn
+const elapsedRedrawInterval = 100 * time.Millisecond
+
+// LiveProgress keeps the progress display current as the tracker changes.
+// On a terminal the step list is redrawn in place; on other writers each
+// step that changes status is appended as a line of its own.
 type LiveProgress struct {
 	renderer *ProgressRenderer
+	out      io.Writer
+	width    int
+	live     bool
+	rows     int
+	paused   bool
+	stop     chan struct{}
+	statuses map[string]config.Status
+	mu       sync.Mutex
 }
 
 // NewLiveProgress creates a new live progress display
 func NewLiveProgress(tracker *config.StepTracker) *LiveProgress {
 	return &LiveProgress{
 		renderer: NewProgressRenderer(tracker),
+		statuses: make(map[string]config.Status),
 	}
 }
 
+// SetCompact toggles compact rendering of the progress display
+func (lp *LiveProgress) SetCompact(compact bool) {
+	lp.renderer.SetCompact(compact)
+}
+
 // Render returns the current progress display
 func (lp *LiveProgress) Render() string {
 	return lp.renderer.Render()
 }
+
+// Start draws the display on out and redraws it whenever the tracker changes,
+// and on a terminal also while a step is running so its elapsed time ticks
+func (lp *LiveProgress) Start(out io.Writer) {
+	lp.mu.Lock()
+	lp.out = out
+	if file, ok := out.(*os.File); ok && term.IsTerminal(int(file.Fd())) {
+		lp.live = true
+		if width, _, err := term.GetSize(int(file.Fd())); err == nil {
+			lp.width = width
+		}
+	}
+	for _, step := range lp.renderer.tracker.GetSteps() {
+		lp.statuses[step.Key] = step.Status
+	}
+	lp.draw()
+	if lp.live {
+		lp.stop = make(chan struct{})
+		go lp.tick(lp.stop)
+	}
+	lp.mu.Unlock()
+
+	lp.renderer.tracker.AttachRefresh(lp.refresh)
+}
+
+// Stop ends the redraws of running steps' elapsed time
+func (lp *LiveProgress) Stop() {
+	lp.mu.Lock()
+	defer lp.mu.Unlock()
+	if lp.stop != nil {
+		close(lp.stop)
+		lp.stop = nil
+	}
+}
+
+// Pause holds back timed redraws until the tracker next changes, so an
+// interactive prompt drawn below the display is left alone
+func (lp *LiveProgress) Pause() {
+	lp.mu.Lock()
+	defer lp.mu.Unlock()
+	lp.paused = true
+}
+
+// Interrupt leaves the display as drawn so that output written after it,
+// such as a prompt, is not drawn over; the next change starts a new display below
+func (lp *LiveProgress) Interrupt() {
+	lp.mu.Lock()
+	defer lp.mu.Unlock()
+	lp.rows = 0
+	lp.paused = true
+}
+
+// tick redraws the display while a step is running until stop is closed
+func (lp *LiveProgress) tick(stop chan struct{}) {
+	ticker := time.NewTicker(elapsedRedrawInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			lp.mu.Lock()
+			if !lp.paused && lp.running() {
+				lp.draw()
+			}
+			lp.mu.Unlock()
+		}
+	}
+}
+
+// running reports whether any step is running
+func (lp *LiveProgress) running() bool {
+	for _, step := range lp.renderer.tracker.GetSteps() {
+		if step.Status == config.StatusRunning {
+			return true
+		}
+	}
+	return false
+}
+
+// refresh redraws the display after a tracker change
+func (lp *LiveProgress) refresh() {
+	lp.mu.Lock()
+	defer lp.mu.Unlock()
+
+	if lp.live {
+		lp.paused = false
+		lp.draw()
+		return
+	}
+
+	// Without a terminal only status changes are printed; sub-progress would flood logs
+	for _, step := range lp.renderer.tracker.GetSteps() {
+		if lp.statuses[step.Key] == step.Status {
+			continue
+		}
+		lp.statuses[step.Key] = step.Status
+		if step.Status == config.StatusRunning {
+			// A line that cannot be redrawn would always read 0.0s
+			step.Started = time.Time{}
+		}
+		_, _ = fmt.Fprintln(lp.out, lp.renderer.renderStep(step))
+	}
+}
+
+// draw prints the display, first moving back over the previous one when live
+func (lp *LiveProgress) draw() {
+	frame := lp.renderer.Render() + "\n"
+	if lp.rows > 0 {
+		// Move to the first row of the previous frame and clear everything below
+		_, _ = fmt.Fprintf(lp.out, "\x1b[%dA\r\x1b[J", lp.rows)
+	}
+	_, _ = io.WriteString(lp.out, frame)
+	if lp.live {
+		lp.rows = frameRows(frame, lp.width)
+	}
+}
+
+// frameRows counts the terminal rows frame occupies, including lines that
+// wrap at width; a width of 0 means lines never wrap
+func frameRows(frame string, width int) int {
+	lines := strings.Split(strings.TrimSuffix(frame, "\n"), "\n")
+	rows := 0
+	for _, line := range lines {
+		cells := lipgloss.Width(line)
+		if width <= 0 || cells <= width {
+			rows++
+			continue
+		}
+		rows += (cells + width - 1) / width
+	}
+	return rows
+}
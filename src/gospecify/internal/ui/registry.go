@@ -0,0 +1,93 @@
+// Package ui provides terminal user interface components
+package ui
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+// Names of the built-in renderers
+const (
+	RendererHuman      = "human"
+	RendererAccessible = "accessible"
+	RendererJSON       = "json"
+	RendererQuiet      = "quiet"
+)
+
+// RendererOptions holds what a renderer factory may draw on
+type RendererOptions struct {
+	// Out receives progress, which --progress-fd may redirect
+	Out io.Writer
+	// Stdout receives documents that must stay with the command's result
+	Stdout io.Writer
+	// Compact collapses finished steps in the progress tree
+	Compact bool
+	// Theme styles the panels of the human renderer
+	Theme *Theme
+}
+
+// RendererFactory creates a renderer from the given options
+type RendererFactory func(opts RendererOptions) OutputRenderer
+
+var (
+	renderersMu sync.RWMutex
+	renderers   = make(map[string]RendererFactory)
+)
+
+func init() {
+	RegisterRenderer(RendererHuman, func(opts RendererOptions) OutputRenderer {
+		return NewHumanRenderer(opts.Out, opts.Compact, opts.Theme)
+	})
+	RegisterRenderer(RendererAccessible, func(opts RendererOptions) OutputRenderer {
+		return NewAccessibleRenderer(opts.Out)
+	})
+	RegisterRenderer(RendererJSON, func(opts RendererOptions) OutputRenderer {
+		return NewJSONRenderer(opts.Stdout)
+	})
+	RegisterRenderer(RendererQuiet, func(opts RendererOptions) OutputRenderer {
+		return NewQuietRenderer()
+	})
+}
+
+// RegisterRenderer makes a renderer available under name. It panics if
+// factory is nil or name is already registered.
+func RegisterRenderer(name string, factory RendererFactory) {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	if factory == nil {
+		panic("ui: RegisterRenderer factory is nil")
+	}
+	if _, exists := renderers[name]; exists {
+		panic(fmt.Sprintf("ui: RegisterRenderer called twice for renderer %s", name))
+	}
+	renderers[name] = factory
+}
+
+// NewRenderer creates the renderer registered under name
+func NewRenderer(name string, opts RendererOptions) (OutputRenderer, error) {
+	renderersMu.RLock()
+	factory, exists := renderers[name]
+	renderersMu.RUnlock()
+	if !exists {
+		return nil, errors.NewValidationError(
+			fmt.Sprintf("unknown renderer %s (available: %s)", name, strings.Join(RendererNames(), ", ")))
+	}
+	return factory(opts), nil
+}
+
+// RendererNames returns the registered renderer names, sorted
+func RendererNames() []string {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+)
+
+func TestNewRendererBuiltins(t *testing.T) {
+	opts := RendererOptions{Out: &bytes.Buffer{}, Stdout: &bytes.Buffer{}, Theme: NewTheme(config.UIConfig{})}
+	for name, want := range map[string]OutputRenderer{
+		RendererHuman:      &HumanRenderer{},
+		RendererAccessible: &AccessibleRenderer{},
+		RendererJSON:       &JSONRenderer{},
+		RendererQuiet:      &QuietRenderer{},
+	} {
+		renderer, err := NewRenderer(name, opts)
+		if err != nil {
+			t.Fatalf("NewRenderer(%q) error = %v", name, err)
+		}
+		if got, expected := fmt.Sprintf("%T", renderer), fmt.Sprintf("%T", want); got != expected {
+			t.Errorf("NewRenderer(%q) = %s, want %s", name, got, expected)
+		}
+	}
+}
+
+// recordingRenderer counts the events it receives
+type recordingRenderer struct {
+	QuietRenderer
+	warnings int
+}
+
+func (r *recordingRenderer) Warn(message string) { r.warnings++ }
+
+func TestRegisterRenderer(t *testing.T) {
+	const name = "test-recording"
+	RegisterRenderer(name, func(opts RendererOptions) OutputRenderer {
+		return &recordingRenderer{}
+	})
+	t.Cleanup(func() {
+		renderersMu.Lock()
+		delete(renderers, name)
+		renderersMu.Unlock()
+	})
+
+	if !slices.Contains(RendererNames(), name) {
+		t.Fatalf("RendererNames() = %v, want it to include %s", RendererNames(), name)
+	}
+	renderer, err := NewRenderer(name, RendererOptions{})
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+	renderer.Warn("careful")
+	if recorder, ok := renderer.(*recordingRenderer); !ok || recorder.warnings != 1 {
+		t.Errorf("NewRenderer() = %#v, want the registered renderer to receive the warning", renderer)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a name twice did not panic")
+		}
+	}()
+	RegisterRenderer(name, func(opts RendererOptions) OutputRenderer { return NewQuietRenderer() })
+}
+
+func TestNewRendererUnknown(t *testing.T) {
+	_, err := NewRenderer("sparkles", RendererOptions{})
+	if errors.CodeOf(err) != errors.ErrCodeValidationError {
+		t.Errorf("NewRenderer() error = %v, want a validation error", err)
+	}
+}
@@ -2,21 +2,28 @@
 package ui
 
 import (
+	"bufio"
 	"fmt"
 	"io"
+	"os"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/jsburckhardt/spec-kit/gospecify/pkg/errors"
+	"golang.org/x/term"
 )
 
 // Selector provides an interactive selection interface
 type Selector struct {
-	list     list.Model
-	selected string
-	quitting bool
+	list       list.Model
+	selected   string
+	quitting   bool
+	accessible bool
 }
 
 // NewSelector creates a new interactive selector
@@ -42,8 +49,8 @@ func NewSelector(prompt string, options map[string]string, defaultKey string) *S
 	l := list.New(items, selectorDelegate{}, 0, 0)
 	l.Title = prompt
 	l.SetShowStatusBar(false)
-	l.SetFilteringEnabled(false)
 	l.SetShowHelp(false)
+	l.FilterInput.Prompt = "Filter: "
 
 	// Set default selection
 	if defaultKey != "" {
@@ -60,8 +67,25 @@ func NewSelector(prompt string, options map[string]string, defaultKey string) *S
 	}
 }
 
-// Run starts the interactive selection
+// StdinIsTerminal reports whether stdin is attached to a terminal, which the
+// interactive list needs; the numbered accessible prompt also reads from pipes
+func StdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// WithAccessible replaces the interactive list with a numbered text prompt
+func (s *Selector) WithAccessible(accessible bool) *Selector {
+	s.accessible = accessible
+	return s
+}
+
+// Run starts the interactive selection. It returns an ErrCodeCancelled error
+// when the user leaves the selector without choosing an option.
 func (s *Selector) Run() (string, error) {
+	if s.accessible {
+		return s.runNumbered(stdin, os.Stdout)
+	}
+
 	p := tea.NewProgram(s)
 	result, err := p.Run()
 	if err != nil {
@@ -69,9 +93,45 @@ func (s *Selector) Run() (string, error) {
 	}
 
 	finalModel := result.(*Selector)
+	if finalModel.selected == "" {
+		return "", errors.NewCancelled("selection cancelled")
+	}
 	return finalModel.selected, nil
 }
 
+// runNumbered lists the options as numbered lines and reads the choice from
+// reader. An empty answer picks the default; end of input cancels.
+func (s *Selector) runNumbered(reader *bufio.Reader, out io.Writer) (string, error) {
+	items := s.list.Items()
+	defaultIndex := s.list.Index()
+
+	_, _ = fmt.Fprintln(out, s.list.Title)
+	for i, item := range items {
+		option := item.(selectorItem)
+		_, _ = fmt.Fprintf(out, "%d. %s (%s)\n", i+1, option.key, option.value)
+	}
+
+	for {
+		_, _ = fmt.Fprintf(out, "Enter a number from 1 to %d (default %d): ", len(items), defaultIndex+1)
+		answer, err := reader.ReadString('\n')
+		if err != nil && answer == "" {
+			return "", errors.NewCancelled("selection cancelled")
+		}
+
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			return items[defaultIndex].(selectorItem).key, nil
+		}
+		if n, convErr := strconv.Atoi(answer); convErr == nil && n >= 1 && n <= len(items) {
+			return items[n-1].(selectorItem).key, nil
+		}
+		_, _ = fmt.Fprintf(out, "%q is not a valid choice.\n", answer)
+		if err != nil {
+			return "", errors.NewCancelled("selection cancelled")
+		}
+	}
+}
+
 // Init initializes the Bubbletea model
 func (s *Selector) Init() tea.Cmd {
 	return nil
@@ -83,14 +143,33 @@ func (s *Selector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			// While filtering this is the highlighted match, not the unfiltered item
 			if item := s.list.SelectedItem(); item != nil {
 				s.selected = item.(selectorItem).key
 				s.quitting = true
 				return s, tea.Quit
 			}
+			return s, nil
 		case key.Matches(msg, key.NewBinding(key.WithKeys("esc", "ctrl+c"))):
 			s.quitting = true
 			return s, tea.Quit
+		case s.list.FilterState() == list.Filtering && key.Matches(msg, key.NewBinding(key.WithKeys("up", "down"))):
+			// The list would otherwise treat arrows as accepting the filter
+			if msg.String() == "up" {
+				s.list.CursorUp()
+			} else {
+				s.list.CursorDown()
+			}
+			return s, nil
+		case msg.Type == tea.KeyRunes && s.list.FilterState() != list.Filtering:
+			// Typing starts filtering straight away rather than after "/"
+			var startCmd, cmd tea.Cmd
+			s.list, startCmd = s.list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+			if string(msg.Runes) == "/" {
+				return s, startCmd
+			}
+			s.list, cmd = s.list.Update(msg)
+			return s, tea.Batch(startCmd, cmd)
 		}
 	case tea.WindowSizeMsg:
 		h, v := docStyle.GetFrameSize()
@@ -117,9 +196,9 @@ type selectorItem struct {
 	value string
 }
 
-// FilterValue returns the value for filtering (not used)
+// FilterValue returns the text typed filters are fuzzy-matched against: the key and display value
 func (i selectorItem) FilterValue() string {
-	return i.key
+	return i.key + " " + i.value
 }
 
 // selectorDelegate handles item rendering
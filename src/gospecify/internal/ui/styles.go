@@ -29,27 +29,6 @@ var (
 
 	WhiteStyle = lipgloss.NewStyle().Foreground(ColorWhite)
 
-	// Panel styles
-	InfoPanel = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorCyan).
-			Padding(1, 2)
-
-	WarningPanel = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorYellow).
-			Padding(1, 2)
-
-	ErrorPanel = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorRed).
-			Padding(1, 2)
-
-	SuccessPanel = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorGreen).
-			Padding(1, 2)
-
 	// Banner style
 	BannerStyle = lipgloss.NewStyle().
 			Foreground(ColorCyan).
@@ -0,0 +1,43 @@
+// Package ui provides terminal user interface components
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+)
+
+// Theme holds the panel styles used to frame command output
+type Theme struct {
+	InfoPanel    lipgloss.Style
+	WarningPanel lipgloss.Style
+	ErrorPanel   lipgloss.Style
+	SuccessPanel lipgloss.Style
+}
+
+// NewTheme builds panel styles from the UI configuration
+func NewTheme(cfg config.UIConfig) *Theme {
+	panel := func(color lipgloss.Color) lipgloss.Style {
+		switch cfg.Border {
+		case config.BorderNone:
+			// Borderless and unpadded so output reads cleanly in logs
+			return lipgloss.NewStyle()
+		case config.BorderThick:
+			return lipgloss.NewStyle().
+				Border(lipgloss.ThickBorder()).
+				BorderForeground(color).
+				Padding(1, 2)
+		default:
+			return lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(color).
+				Padding(1, 2)
+		}
+	}
+
+	return &Theme{
+		InfoPanel:    panel(ColorCyan),
+		WarningPanel: panel(ColorYellow),
+		ErrorPanel:   panel(ColorRed),
+		SuccessPanel: panel(ColorGreen),
+	}
+}
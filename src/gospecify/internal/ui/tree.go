@@ -0,0 +1,70 @@
+// Package ui provides terminal user interface components
+package ui
+
+import (
+	"sort"
+	"strings"
+)
+
+// treeNode is a directory or file in a rendered file tree
+type treeNode struct {
+	name     string
+	children map[string]*treeNode
+}
+
+// RenderTree renders slash-separated relative paths as an ASCII tree below
+// root, like the tree command. At the top level .specify/ comes first, then
+// the other directories (agent folders), then root files.
+func RenderTree(root string, paths []string) string {
+	top := &treeNode{name: root, children: make(map[string]*treeNode)}
+	for _, p := range paths {
+		node := top
+		for _, part := range strings.Split(strings.Trim(p, "/"), "/") {
+			if part == "" || part == "." {
+				continue
+			}
+			child, ok := node.children[part]
+			if !ok {
+				child = &treeNode{name: part, children: make(map[string]*treeNode)}
+				node.children[part] = child
+			}
+			node = child
+		}
+	}
+
+	var output strings.Builder
+	output.WriteString(root + "\n")
+	renderTreeChildren(&output, top, "", true)
+	return strings.TrimRight(output.String(), "\n")
+}
+
+// renderTreeChildren writes the children of node, directories before files
+func renderTreeChildren(output *strings.Builder, node *treeNode, prefix string, topLevel bool) {
+	children := make([]*treeNode, 0, len(node.children))
+	for _, child := range node.children {
+		children = append(children, child)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		a, b := children[i], children[j]
+		if topLevel && (a.name == ".specify") != (b.name == ".specify") {
+			return a.name == ".specify"
+		}
+		if (len(a.children) > 0) != (len(b.children) > 0) {
+			return len(a.children) > 0
+		}
+		return a.name < b.name
+	})
+
+	for i, child := range children {
+		connector, indent := "├── ", "│   "
+		if i == len(children)-1 {
+			connector, indent = "└── ", "    "
+		}
+		name := child.name
+		if len(child.children) > 0 {
+			name += "/"
+		}
+		output.WriteString(prefix + connector + name + "\n")
+		renderTreeChildren(output, child, prefix+indent, false)
+	}
+}
@@ -1,7 +1,10 @@
 // Package errors provides custom error types for gospecify
 package errors
 
-import "fmt"
+import (
+	stderrors "errors"
+	"fmt"
+)
 
 // Error represents a gospecify error with additional context
 type Error struct {
@@ -35,8 +38,64 @@ const (
 	ErrCodeGitHubAPIError  = "GITHUB_API_ERROR"
 	ErrCodeAssetNotFound   = "ASSET_NOT_FOUND"
 	ErrCodeToolNotFound    = "TOOL_NOT_FOUND"
+	ErrCodeAssetCorrupted  = "ASSET_CORRUPTED"
+	ErrCodeDiskSpace       = "INSUFFICIENT_DISK_SPACE"
+	ErrCodeCancelled       = "CANCELLED"
 )
 
+// Process exit codes for each error code, so scripts can branch on the kind
+// of failure. Anything without a code exits with ExitGeneral.
+const (
+	ExitGeneral         = 1
+	ExitNetworkError    = 2
+	ExitFileSystemError = 3
+	ExitValidationError = 4
+	ExitInvalidConfig   = 5
+	ExitGitError        = 6
+	ExitTemplateError   = 7
+	ExitScriptError     = 8
+	ExitGitHubAPIError  = 9
+	ExitAssetNotFound   = 10
+	ExitToolNotFound    = 11
+	ExitAssetCorrupted  = 12
+	ExitDiskSpace       = 13
+	// ExitCancelled follows the shell convention for a process stopped by Ctrl+C
+	ExitCancelled = 130
+)
+
+var exitCodes = map[string]int{
+	ErrCodeNetworkError:    ExitNetworkError,
+	ErrCodeFileSystemError: ExitFileSystemError,
+	ErrCodeValidationError: ExitValidationError,
+	ErrCodeInvalidConfig:   ExitInvalidConfig,
+	ErrCodeGitError:        ExitGitError,
+	ErrCodeTemplateError:   ExitTemplateError,
+	ErrCodeScriptError:     ExitScriptError,
+	ErrCodeGitHubAPIError:  ExitGitHubAPIError,
+	ErrCodeAssetNotFound:   ExitAssetNotFound,
+	ErrCodeToolNotFound:    ExitToolNotFound,
+	ErrCodeAssetCorrupted:  ExitAssetCorrupted,
+	ErrCodeDiskSpace:       ExitDiskSpace,
+	ErrCodeCancelled:       ExitCancelled,
+}
+
+// ExitCode returns the process exit code for the error's code
+func (e *Error) ExitCode() int {
+	if code, ok := exitCodes[e.Code]; ok {
+		return code
+	}
+	return ExitGeneral
+}
+
+// CodeOf returns the code of the first *Error in err's chain, or an empty string
+func CodeOf(err error) string {
+	var e *Error
+	if stderrors.As(err, &e) {
+		return e.Code
+	}
+	return ""
+}
+
 // New creates a new Error with the given code and message
 func New(code, message string) *Error {
 	return &Error{
@@ -103,3 +162,22 @@ func NewAssetNotFound(assetName string) *Error {
 func NewToolNotFound(toolName string) *Error {
 	return New(ErrCodeToolNotFound, fmt.Sprintf("required tool not found: %s", toolName))
 }
+
+// NewAssetCorrupted creates an embedded asset integrity error
+func NewAssetCorrupted(assetName, reason string) *Error {
+	return New(ErrCodeAssetCorrupted,
+		fmt.Sprintf("embedded asset %s failed integrity check (%s); the gospecify binary may be corrupt, please reinstall it", assetName, reason))
+}
+
+// NewCancelled creates an error for an operation the user cancelled
+func NewCancelled(message string) *Error {
+	return New(ErrCodeCancelled, message)
+}
+
+// NewInsufficientDiskSpace creates an error for a filesystem without room for the files to be written
+func NewInsufficientDiskSpace(path string, required, available uint64) *Error {
+	const mib = 1024 * 1024
+	return New(ErrCodeDiskSpace,
+		fmt.Sprintf("insufficient disk space in %s: %.1f MiB required, %.1f MiB available",
+			path, float64(required)/mib, float64(available)/mib))
+}
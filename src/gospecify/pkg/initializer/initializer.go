@@ -0,0 +1,130 @@
+// Package initializer exposes project initialization as a Go API, for tools
+// that embed gospecify instead of running the CLI
+package initializer
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/config"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/scaffold"
+	"github.com/jsburckhardt/spec-kit/gospecify/internal/ui"
+)
+
+// Config is the project configuration accepted by Initialize. Each field
+// corresponds to the init flag of the same name.
+type Config struct {
+	// Name is the project directory, created below the working directory
+	Name string
+	// Here initializes the working directory instead of Name
+	Here bool
+	// AIAssistant is an assistant key such as "claude", or "all"
+	AIAssistant string
+	// ScriptType is "sh", "ps", "fish" or "nu"
+	ScriptType  string
+	TemplateSet string
+	// Force allows initializing a directory that is not empty
+	Force bool
+	// IgnoreAgentTools skips checking that the assistant's CLI is installed
+	IgnoreAgentTools bool
+	NoGit            bool
+	Branch           string
+	Owner            string
+	// Values fills in template placeholders, as --set does
+	Values map[string]string
+	// Commands limits the installed commands; empty installs all
+	Commands []string
+	DryRun   bool
+	// GitHubToken is used for release downloads; empty falls back to the environment
+	GitHubToken  string
+	UseRelease   bool
+	TemplateRepo string
+	TemplateRef  string
+	TemplateDir  string
+	// CreatedAt is the timestamp recorded in generated files; zero means now
+	CreatedAt time.Time
+	// Logger receives the run's log records; nil discards them
+	Logger *slog.Logger
+}
+
+// Result describes a completed initialization
+type Result struct {
+	// Path is the absolute project directory
+	Path string
+	// AIAssistant is the selected assistant, or "all"
+	AIAssistant string
+	// AIAssistants lists every assistant scaffolded
+	AIAssistants []string
+	ScriptType   string
+	// Files lists the project-relative paths written, sorted
+	Files []string
+	// Git describes what happened to the git repository, e.g. "initialized"
+	Git string
+}
+
+// Initialize scaffolds a Specify project as 'gospecify init' would, without
+// printing anything or prompting. AIAssistant and ScriptType default like
+// --yes does; unset fields take the CLI's flag defaults. As with the CLI,
+// Name (or Here) is resolved against the working directory.
+func Initialize(ctx context.Context, cfg Config) (Result, error) {
+	projectCfg := config.ProjectConfig{
+		Name:             cfg.Name,
+		Here:             cfg.Here,
+		AIAssistant:      cfg.AIAssistant,
+		ScriptType:       cfg.ScriptType,
+		TemplateSet:      cfg.TemplateSet,
+		Force:            cfg.Force,
+		IgnoreTools:      cfg.IgnoreAgentTools,
+		NoGit:            cfg.NoGit,
+		Branch:           cfg.Branch,
+		Owner:            cfg.Owner,
+		Values:           cfg.Values,
+		Commands:         cfg.Commands,
+		DryRun:           cfg.DryRun,
+		GitHubToken:      cfg.GitHubToken,
+		UseRelease:       cfg.UseRelease,
+		TemplateRepo:     cfg.TemplateRepo,
+		TemplateRef:      cfg.TemplateRef,
+		TemplateDir:      cfg.TemplateDir,
+		CreatedAt:        cfg.CreatedAt,
+		Logger:           cfg.Logger,
+		NonInteractive:   true,
+		ShowTree:         true,
+		Quiet:            true,
+		WriteConcurrency: config.DefaultWriteConcurrency,
+		LogLevel:         config.DefaultLogLevel,
+		CacheTTL:         config.DefaultReleaseCacheTTL,
+	}
+	if projectCfg.Logger == nil {
+		projectCfg.Logger = slog.New(slog.DiscardHandler)
+	}
+	if projectCfg.Branch == "" {
+		projectCfg.Branch = config.DefaultBranch
+	}
+	if projectCfg.TemplateSet == "" {
+		projectCfg.TemplateSet = config.DefaultTemplateSet
+	}
+	if projectCfg.CreatedAt.IsZero() {
+		createdAt, err := config.ResolveTimestamp("")
+		if err != nil {
+			return Result{}, err
+		}
+		projectCfg.CreatedAt = createdAt
+	}
+
+	result, err := scaffold.Run(ctx, &projectCfg, ui.NewQuietRenderer())
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Path:         result.Path,
+		AIAssistant:  result.AIAssistant,
+		AIAssistants: result.AIAssistants,
+		ScriptType:   result.ScriptType,
+		Files:        slices.Sorted(slices.Values(result.Files)),
+		Git:          result.Git,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package initializer
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestInitialize(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	var logs bytes.Buffer
+	result, err := Initialize(context.Background(), Config{
+		Name:             "demo",
+		AIAssistant:      "claude",
+		ScriptType:       "sh",
+		IgnoreAgentTools: true,
+		NoGit:            true,
+		Owner:            "Ada",
+		CreatedAt:        time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Logger:           slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelInfo})),
+	})
+	if err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	if want := filepath.Join(dir, "demo"); result.Path != want {
+		t.Errorf("Path = %s, want %s", result.Path, want)
+	}
+	if result.AIAssistant != "claude" || result.ScriptType != "sh" || result.Git != "skipped" {
+		t.Errorf("result = %+v, want claude, sh and git skipped", result)
+	}
+	if !slices.IsSorted(result.Files) || !slices.Contains(result.Files, ".claude/commands/plan.md") {
+		t.Errorf("Files = %v, want a sorted list including .claude/commands/plan.md", result.Files)
+	}
+	for _, file := range result.Files {
+		if _, err := os.Stat(filepath.Join(result.Path, filepath.FromSlash(file))); err != nil {
+			t.Errorf("%s is listed but missing: %v", file, err)
+		}
+	}
+	if logs.Len() == 0 {
+		t.Error("the supplied logger received nothing")
+	}
+}
+
+func TestInitializeDefaults(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	result, err := Initialize(context.Background(), Config{
+		Name:             "demo",
+		IgnoreAgentTools: true,
+		NoGit:            true,
+		DryRun:           true,
+	})
+	if err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	if result.AIAssistant != "claude" || result.ScriptType == "" {
+		t.Errorf("result = %+v, want the default assistant and a script type", result)
+	}
+	if _, err := os.Stat(result.Path); !os.IsNotExist(err) {
+		t.Errorf("dry run created %s (stat error = %v)", result.Path, err)
+	}
+}
@@ -80,6 +80,12 @@ setup_dist() {
     mkdir -p dist/
 }
 
+# Regenerate the embedded asset checksum manifest
+generate_manifest() {
+    log_info "Generating asset manifest..."
+    "$(dirname "$0")/gen-asset-manifest.sh"
+}
+
 # Build for all platforms
 build_all() {
     log_info "Starting build for all platforms..."
@@ -173,6 +179,7 @@ main() {
         all)
             clean
             setup_dist
+            generate_manifest
             test_build
             build_all
             ;;
@@ -181,6 +188,7 @@ main() {
             ;;
         test)
             setup_dist
+            generate_manifest
             test_build
             ;;
         help|--help|-h)
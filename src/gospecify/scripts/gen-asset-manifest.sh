@@ -0,0 +1,25 @@
+#!/bin/bash
+# Generate the SHA-256 manifest for embedded assets
+#
+# The manifest is embedded into the binary alongside the assets and is
+# verified at startup to detect tampered or truncated builds.
+
+set -euo pipefail
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+ROOT_DIR="$(dirname "$SCRIPT_DIR")"
+MANIFEST="${ROOT_DIR}/assets.sha256"
+
+cd "$ROOT_DIR"
+
+if command -v sha256sum >/dev/null 2>&1; then
+    HASH_CMD=(sha256sum)
+else
+    HASH_CMD=(shasum -a 256)
+fi
+
+find assets -type f | LC_ALL=C sort | while read -r file; do
+    "${HASH_CMD[@]}" "$file"
+done > "$MANIFEST"
+
+echo "Wrote $(wc -l < "$MANIFEST" | tr -d ' ') entries to ${MANIFEST#"$ROOT_DIR"/}"